@@ -1,23 +1,25 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/rivo/tview"
 	"github.com/sirupsen/logrus"
 	"github.com/vitorqb/addledger/internal/app"
-	"github.com/vitorqb/addledger/internal/config"
+	configmod "github.com/vitorqb/addledger/internal/config"
 	"github.com/vitorqb/addledger/internal/controller"
 	"github.com/vitorqb/addledger/internal/display"
 	"github.com/vitorqb/addledger/internal/eventbus"
 	"github.com/vitorqb/addledger/internal/injector"
+	"github.com/vitorqb/addledger/internal/services/statementimporter"
 	"github.com/vitorqb/addledger/internal/services/statementloader"
 )
 
 func main() {
 
 	// Loads config
-	config, err := config.LoadFromCommandLine()
+	config, err := configmod.LoadFromCommandLine()
 	if err != nil {
 		logrus.WithError(err).Fatal("Error loading config.")
 	}
@@ -47,6 +49,10 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to load transactions")
 	}
+	err = metaLoader.LoadDefaultCommodity()
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load default commodity")
+	}
 
 	// Opens the destination file
 	destFile, err := os.OpenFile(config.DestFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
@@ -60,7 +66,7 @@ func main() {
 	eventBus := eventbus.New()
 
 	// Starts a date guesser
-	dateGuesser, err := injector.DateGuesser()
+	dateGuesser, err := injector.DateGuesser(config)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to load date guesser")
 	}
@@ -82,11 +88,21 @@ func main() {
 
 	// Prepares a statement loader
 	statementReader := injector.StatementReader()
-	statementLoaderSvc := statementloader.New(state, statementReader)
+	stringMatcher, err := injector.StringMatcher()
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load string matcher")
+	}
+	statementProgressStore, err := injector.StatementProgressStore()
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load statement progress store")
+	}
+	app.LinkStatementProgressStore(state, statementProgressStore)
 
 	// Starts a user messenger
 	userMessenger := injector.UserMessenger(state)
 
+	statementLoaderSvc := statementloader.New(state, statementReader, stringMatcher, statementProgressStore, userMessenger)
+
 	// Starts a new controller
 	controller, err := controller.NewController(state,
 		controller.WithOutput(destFile),
@@ -103,19 +119,47 @@ func main() {
 
 	// Starts the AmmountGuesserEngine. Note it's linked to state refresh
 	// so we don't need it's instance.
-	ammountGuesser := injector.AmmountGuesser()
+	ammountGuesser := injector.AmmountGuesser(config)
 	app.LinkAmmountGuesser(state, ammountGuesser)
 
 	// Start an account guesser
-	accountGuesser, err := injector.AccountGuesser(state)
+	accountGuesser, err := injector.AccountGuesser(state, config.AccountGuesserConfig)
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to load account guesser")
 	}
 	app.LinkAccountGuesser(state, accountGuesser)
 
+	// Maybe import a statement without the TUI
+	if config.Command == configmod.ImportCommand {
+		guessers := statementimporter.Guessers{
+			Date:               dateGuesser,
+			Account:            accountGuesser,
+			Ammount:            ammountGuesser,
+			TransactionMatcher: transactionMatcher,
+		}
+		importer := statementimporter.New(state, statementLoaderSvc, statementProgressStore, guessers, printer)
+		options := statementimporter.Options{Threshold: config.ImportConfig.Threshold, Output: destFile}
+		if config.ImportConfig.ReviewFile != "" {
+			reviewFile, err := os.OpenFile(config.ImportConfig.ReviewFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+			if err != nil {
+				logrus.WithError(err).
+					WithField("file", config.ImportConfig.ReviewFile).
+					Fatal("Failed to open file")
+			}
+			defer reviewFile.Close()
+			options.Review = reviewFile
+		}
+		result, err := importer.Import(config.CSVStatementFile, config.CSVStatementPreset, config.CSVStatementFormat, options)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to import statement")
+		}
+		fmt.Printf("Imported %d transactions, %d pending.\n", len(result.Imported), len(result.Pending))
+		return
+	}
+
 	// Maybe load a CSV statement
 	if config.CSVStatementFile != "" {
-		err = statementLoaderSvc.LoadFromFiles(config.CSVStatementFile, config.CSVStatementPreset)
+		err = statementLoaderSvc.LoadFromFiles(config.CSVStatementFile, config.CSVStatementPreset, config.CSVStatementFormat)
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to load csv statement")
 		}
@@ -3,6 +3,8 @@
 package accountguesser
 
 import (
+	"sort"
+
 	"github.com/vitorqb/addledger/internal/finance"
 	"github.com/vitorqb/addledger/internal/journal"
 )
@@ -34,6 +36,39 @@ type Inputs struct {
 // AccountGuesser is a strategy for guessing the account an user may want for an journal entry.
 type AccountGuesser interface {
 	Guess(inputs Inputs) (guess journal.Account, success bool)
+	// Rank returns the accounts the guesser suggests, best first. The first
+	// one is the one returned by Guess.
+	Rank(inputs Inputs) []Candidate
+}
+
+// Candidate is an account suggested by an AccountGuesser.
+type Candidate struct {
+	Account journal.Account
+	// Score is the probability of the account (between 0 and 1), for
+	// guessers that can calibrate it (e.g. naive Bayes).
+	Score float64
+	// Scored is false for guessers without scores, whose candidates are
+	// only ranked by the position of the guesser.
+	Scored bool
+}
+
+// rankGuess ranks the single guess of a guesser that has no scores.
+func rankGuess(guess journal.Account, success bool) []Candidate {
+	if !success {
+		return []Candidate{}
+	}
+	return []Candidate{{Account: guess}}
+}
+
+// sortCandidates sorts candidates by score, best first (and by account, for
+// ties).
+func sortCandidates(candidates []Candidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Account < candidates[j].Account
+	})
 }
 
 var _ AccountGuesser = &MatchedTransactionsGuesser{}
@@ -65,6 +100,11 @@ func (*MatchedTransactionsGuesser) Guess(inputs Inputs) (acc journal.Account, su
 	return journal.Account(matchedPosting.Account), true
 }
 
+// Rank implements AccountGuesser.
+func (ag *MatchedTransactionsGuesser) Rank(inputs Inputs) []Candidate {
+	return rankGuess(ag.Guess(inputs))
+}
+
 // NewMatchedTransactionsAccountGuesser returns a new implementation of MatchedTransactionsGuesser
 func NewMatchedTransactionsAccountGuesser() (*MatchedTransactionsGuesser, error) {
 	return &MatchedTransactionsGuesser{}, nil
@@ -89,6 +129,11 @@ func (ag *LastTransactionAccountGuesser) Guess(inputs Inputs) (acc journal.Accou
 	return journal.Account(firstPosting.Account), true
 }
 
+// Rank implements AccountGuesser.
+func (ag *LastTransactionAccountGuesser) Rank(inputs Inputs) []Candidate {
+	return rankGuess(ag.Guess(inputs))
+}
+
 func NewLastTransactionAccountGuesser() (*LastTransactionAccountGuesser, error) {
 	return &LastTransactionAccountGuesser{}, nil
 }
@@ -101,19 +146,55 @@ var _ AccountGuesser = &StatementAccountGuesser{}
 
 // Guess tries to guess an account based on a loaded statement entry. If there
 // is a statement entry with an acconut that does not yet exist in the
-// input postings, it returns that account.
+// input postings, it returns that account. Once the statement entry account
+// has been entered, the accounts of the statement entry splits (if any) are
+// returned, in order, or the statement entry counter account (if any).
 func (ag *StatementAccountGuesser) Guess(inputs Inputs) (acc journal.Account, success bool) {
 	if inputs.StatementEntry.Account == "" {
 		return "", false
 	}
 	for _, posting := range inputs.PostingInputs {
 		if posting.Account == inputs.StatementEntry.Account {
-			return "", false
+			if acc, success := guessFromSplits(inputs); success {
+				return acc, success
+			}
+			return guessCounterAccount(inputs)
 		}
 	}
 	return journal.Account(inputs.StatementEntry.Account), true
 }
 
+// guessCounterAccount returns the statement entry counter account, unless it
+// has already been entered.
+func guessCounterAccount(inputs Inputs) (acc journal.Account, success bool) {
+	counterAccount := inputs.StatementEntry.CounterAccount
+	if counterAccount == "" {
+		return "", false
+	}
+	for _, posting := range inputs.PostingInputs {
+		if posting.Account == counterAccount {
+			return "", false
+		}
+	}
+	return journal.Account(counterAccount), true
+}
+
+// guessFromSplits returns the account of the statement entry split for the
+// posting being entered, assuming the first posting is the statement one.
+func guessFromSplits(inputs Inputs) (acc journal.Account, success bool) {
+	splitIndex := len(inputs.PostingInputs) - 1
+	splits := inputs.StatementEntry.Splits
+	if splitIndex < 0 || splitIndex >= len(splits) || splits[splitIndex].Account == "" {
+		return "", false
+	}
+	return journal.Account(splits[splitIndex].Account), true
+}
+
+// Rank implements AccountGuesser.
+func (ag *StatementAccountGuesser) Rank(inputs Inputs) []Candidate {
+	return rankGuess(ag.Guess(inputs))
+}
+
 func NewStatementAccountGuesser() (*StatementAccountGuesser, error) {
 	return &StatementAccountGuesser{}, nil
 }
@@ -135,6 +216,35 @@ func (ag *CompositeAccountGuesser) Guess(inputs Inputs) (guess journal.Account,
 	return journal.Account(""), false
 }
 
+// Rank implements AccountGuesser. The candidates of the composed guessers
+// are merged in the order of the guessers, so that the first one is the
+// guess. Accounts suggested by more than one guesser are kept only the first
+// time, with its best score.
+func (ag *CompositeAccountGuesser) Rank(inputs Inputs) []Candidate {
+	candidates := []Candidate{}
+	seen := map[journal.Account]int{}
+	for _, composedGuesser := range ag.composedGuessers {
+		for _, candidate := range composedGuesser.Rank(inputs) {
+			if i, found := seen[candidate.Account]; found {
+				// Keeps the position of the first guesser, with the best
+				// score of any guesser.
+				if candidate.Scored && (!candidates[i].Scored || candidate.Score > candidates[i].Score) {
+					candidates[i].Score, candidates[i].Scored = candidate.Score, true
+				}
+				continue
+			}
+			seen[candidate.Account] = len(candidates)
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// Guessers returns the composed guessers, in order.
+func (ag *CompositeAccountGuesser) Guessers() []AccountGuesser {
+	return ag.composedGuessers
+}
+
 func NewCompositeAccountGuesser(accGuessers ...AccountGuesser) (*CompositeAccountGuesser, error) {
 	return &CompositeAccountGuesser{accGuessers}, nil
 }
@@ -201,6 +201,40 @@ func TestStatementAccountGuesser(t *testing.T) {
 			success:  true,
 			expected: "savings",
 		},
+		{
+			name: "statement entry with splits",
+			sEntry: finance.StatementEntry{
+				Account: "savings",
+				Splits:  []finance.StatementEntrySplit{{Account: "food"}, {Account: "home"}},
+			},
+			input:    []journal.Posting{{Account: "savings"}, {Account: "food"}},
+			success:  true,
+			expected: "home",
+		},
+		{
+			name: "statement entry with splits already entered",
+			sEntry: finance.StatementEntry{
+				Account: "savings",
+				Splits:  []finance.StatementEntrySplit{{Account: "food"}},
+			},
+			input:    []journal.Posting{{Account: "savings"}, {Account: "food"}},
+			success:  false,
+			expected: "",
+		},
+		{
+			name:     "statement entry with counter account",
+			sEntry:   finance.StatementEntry{Account: "savings", CounterAccount: "food"},
+			input:    []journal.Posting{{Account: "savings"}},
+			success:  true,
+			expected: "food",
+		},
+		{
+			name:     "statement entry with counter account already entered",
+			sEntry:   finance.StatementEntry{Account: "savings", CounterAccount: "food"},
+			input:    []journal.Posting{{Account: "savings"}, {Account: "food"}},
+			success:  false,
+			expected: "",
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -315,3 +349,28 @@ func TestCompositeAccountGuesser(t *testing.T) {
 		})
 	}
 }
+
+func TestCompositeAccountGuesserRank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	inputs := Inputs{Description: "description"}
+	accountGuesserOne := NewMockAccountGuesser(ctrl)
+	accountGuesserOne.EXPECT().Rank(inputs).Return([]Candidate{{Account: "savings"}})
+	accountGuesserTwo := NewMockAccountGuesser(ctrl)
+	accountGuesserTwo.EXPECT().Rank(inputs).Return([]Candidate{})
+	accountGuesserThree := NewMockAccountGuesser(ctrl)
+	accountGuesserThree.EXPECT().Rank(inputs).Return([]Candidate{
+		{Account: "checking", Score: 0.7, Scored: true},
+		{Account: "savings", Score: 0.2, Scored: true},
+	})
+	accountGuesserFour := NewMockAccountGuesser(ctrl)
+	accountGuesserFour.EXPECT().Rank(inputs).Return([]Candidate{{Account: "cash"}, {Account: "checking"}})
+	accountGuesser, err := NewCompositeAccountGuesser(accountGuesserOne, accountGuesserTwo, accountGuesserThree, accountGuesserFour)
+	assert.Nil(t, err)
+	candidates := accountGuesser.Rank(inputs)
+	assert.Equal(t, []Candidate{
+		{Account: "savings", Score: 0.2, Scored: true},
+		{Account: "checking", Score: 0.7, Scored: true},
+		{Account: "cash"},
+	}, candidates)
+}
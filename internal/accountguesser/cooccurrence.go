@@ -0,0 +1,129 @@
+package accountguesser
+
+import (
+	"math"
+	"time"
+
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+// CoOccurrenceHalfLife is the age after which a transaction weights half as
+// much in the co-occurrence statistics.
+const CoOccurrenceHalfLife = 180 * 24 * time.Hour
+
+// coOccurrenceEpoch is the reference date for the recency weights.
+var coOccurrenceEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CoOccurrenceAccountGuesser guesses the next account from the accounts
+// already entered, based on which accounts appear together in the
+// transaction history. Recent transactions weight more. It's trained
+// when created, and must be kept in sync with the history (see Sync), which
+// is not read from the Inputs.
+type CoOccurrenceAccountGuesser struct {
+	// weights is the (recency weighted) number of transactions in which two
+	// accounts appear together.
+	weights map[journal.Account]map[journal.Account]float64
+	history historyTracker
+}
+
+var _ AccountGuesser = &CoOccurrenceAccountGuesser{}
+
+// Train resets the guesser and trains it from a transaction history.
+func (ag *CoOccurrenceAccountGuesser) Train(history []journal.Transaction) {
+	ag.weights = map[journal.Account]map[journal.Account]float64{}
+	ag.history.reset()
+	for _, transaction := range history {
+		ag.Learn(transaction)
+	}
+}
+
+// Learn updates the guesser with a new transaction.
+func (ag *CoOccurrenceAccountGuesser) Learn(transaction journal.Transaction) {
+	ag.history.track(transaction)
+	weight := recencyWeight(transaction.Date)
+	accounts := map[journal.Account]bool{}
+	for _, posting := range transaction.Posting {
+		accounts[journal.Account(posting.Account)] = true
+	}
+	for account := range accounts {
+		for other := range accounts {
+			if account == other {
+				continue
+			}
+			if ag.weights[account] == nil {
+				ag.weights[account] = map[journal.Account]float64{}
+			}
+			ag.weights[account][other] += weight
+		}
+	}
+}
+
+// Sync trains the guesser on the transactions appended to the history since
+// the last sync, or on the whole history if it was replaced.
+func (ag *CoOccurrenceAccountGuesser) Sync(history []journal.Transaction) {
+	if ag.weights == nil || ag.history.replaced(history) {
+		ag.Train(history)
+		return
+	}
+	for _, transaction := range ag.history.appended(history) {
+		ag.Learn(transaction)
+	}
+}
+
+// Rank implements AccountGuesser. The score of an account is its share
+// of the co-occurrences with the accounts already entered. Nothing is ranked
+// before an account is entered.
+func (ag *CoOccurrenceAccountGuesser) Rank(inputs Inputs) []Candidate {
+	entered := map[journal.Account]bool{}
+	for _, posting := range inputs.PostingInputs {
+		entered[journal.Account(posting.Account)] = true
+	}
+	scores := map[journal.Account]float64{}
+	total := 0.0
+	for account := range entered {
+		for other, weight := range ag.weights[account] {
+			if entered[other] {
+				continue
+			}
+			scores[other] += weight
+			total += weight
+		}
+	}
+	candidates := []Candidate{}
+	if total == 0 {
+		return candidates
+	}
+	for account, score := range scores {
+		candidates = append(candidates, Candidate{Account: account, Score: score / total, Scored: true})
+	}
+	sortCandidates(candidates)
+	return candidates
+}
+
+// Guess implements AccountGuesser.
+func (ag *CoOccurrenceAccountGuesser) Guess(inputs Inputs) (acc journal.Account, success bool) {
+	candidates := ag.Rank(inputs)
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return candidates[0].Account, true
+}
+
+// NewCoOccurrenceAccountGuesser returns a new CoOccurrenceAccountGuesser, trained
+// on a transaction history.
+func NewCoOccurrenceAccountGuesser(history []journal.Transaction) (*CoOccurrenceAccountGuesser, error) {
+	guesser := &CoOccurrenceAccountGuesser{}
+	guesser.Train(history)
+	return guesser, nil
+}
+
+// recencyWeight returns the weight of a transaction from its date, doubling
+// every CoOccurrenceHalfLife. Only the ratio between weights matters, so
+// they are relative to a fixed epoch and don't need to decay over time.
+// Transactions without a date weight as if they were from the epoch.
+func recencyWeight(date time.Time) float64 {
+	if date.IsZero() {
+		return 1
+	}
+	return math.Exp2(float64(date.Sub(coOccurrenceEpoch)) / float64(CoOccurrenceHalfLife))
+}
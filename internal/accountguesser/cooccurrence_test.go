@@ -0,0 +1,124 @@
+package accountguesser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/accountguesser"
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+func TestCoOccurrenceAccountGuesser(t *testing.T) {
+	transaction := func(date time.Time, accounts ...string) journal.Transaction {
+		postings := []journal.Posting{}
+		for _, account := range accounts {
+			postings = append(postings, journal.Posting{Account: account})
+		}
+		return journal.Transaction{Date: date, Posting: postings}
+	}
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := []journal.Transaction{
+		transaction(old, "liabilities:amex", "expenses:restaurants"),
+		transaction(old, "liabilities:amex", "expenses:restaurants"),
+		transaction(recent, "liabilities:amex", "expenses:travel"),
+		transaction(recent, "assets:bank", "expenses:groceries"),
+		transaction(recent, "assets:bank", "liabilities:amex"),
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, guesser *CoOccurrenceAccountGuesser)
+	}
+	testcases := []testcase{
+		{
+			name: "Nothing before an account is entered",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				_, success := guesser.Guess(Inputs{})
+				assert.False(t, success)
+			},
+		},
+		{
+			name: "Nothing for unknown accounts",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				_, success := guesser.Guess(Inputs{
+					PostingInputs: []journal.Posting{{Account: "assets:other"}},
+				})
+				assert.False(t, success)
+			},
+		},
+		{
+			name: "Suggests the account that most appears with the entered one",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				account, success := guesser.Guess(Inputs{
+					PostingInputs: []journal.Posting{{Account: "assets:bank"}},
+				})
+				assert.True(t, success)
+				assert.Equal(t, journal.Account("expenses:groceries"), account)
+			},
+		},
+		{
+			name: "Recent transactions weight more",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				candidates := guesser.Rank(Inputs{
+					PostingInputs: []journal.Posting{{Account: "liabilities:amex"}},
+				})
+				assert.Len(t, candidates, 3)
+				// Ties are sorted by account.
+				assert.Equal(t, journal.Account("assets:bank"), candidates[0].Account)
+				assert.Equal(t, journal.Account("expenses:travel"), candidates[1].Account)
+				assert.Equal(t, candidates[0].Score, candidates[1].Score)
+				assert.Equal(t, journal.Account("expenses:restaurants"), candidates[2].Account)
+				assert.Less(t, candidates[2].Score, candidates[1].Score)
+				assert.InDelta(t, 1, candidates[0].Score+candidates[1].Score+candidates[2].Score, 0.0001)
+			},
+		},
+		{
+			name: "Does not suggest accounts already entered",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				candidates := guesser.Rank(Inputs{
+					PostingInputs: []journal.Posting{{Account: "assets:bank"}, {Account: "liabilities:amex"}},
+				})
+				for _, candidate := range candidates {
+					assert.NotContains(t, []journal.Account{"assets:bank", "liabilities:amex"}, candidate.Account)
+				}
+				assert.Equal(t, journal.Account("expenses:groceries"), candidates[0].Account)
+			},
+		},
+		{
+			name: "Learns transactions appended to the history",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				inputs := Inputs{
+					PostingInputs: []journal.Posting{{Account: "assets:bank"}},
+				}
+				guesser.Guess(inputs)
+				newer := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+				guesser.Sync(append(append([]journal.Transaction{}, history...),
+					transaction(newer, "assets:bank", "expenses:rent"),
+					transaction(newer, "assets:bank", "expenses:rent"),
+				))
+				account, _ := guesser.Guess(inputs)
+				assert.Equal(t, journal.Account("expenses:rent"), account)
+			},
+		},
+		{
+			name: "Retrains when the history is replaced",
+			run: func(t *testing.T, guesser *CoOccurrenceAccountGuesser) {
+				inputs := Inputs{
+					PostingInputs: []journal.Posting{{Account: "assets:bank"}},
+				}
+				guesser.Guess(inputs)
+				guesser.Sync([]journal.Transaction{transaction(recent, "assets:bank", "income:salary")})
+				candidates := guesser.Rank(inputs)
+				assert.Equal(t, []Candidate{{Account: "income:salary", Score: 1, Scored: true}}, candidates)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			guesser, err := NewCoOccurrenceAccountGuesser(history)
+			assert.Nil(t, err)
+			tc.run(t, guesser)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package accountguesser
+
+import (
+	"reflect"
+
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+// historyTracker tracks which transactions of a history a guesser was
+// trained on, so it can learn only the ones appended since.
+type historyTracker struct {
+	// trained is the number of transactions trained on. first and last are
+	// copies of the first and last of them, used to detect whether the
+	// history was replaced.
+	trained     int
+	first, last journal.Transaction
+}
+
+// track records that a transaction was trained on.
+func (h *historyTracker) track(transaction journal.Transaction) {
+	if h.trained == 0 {
+		h.first = transaction
+	}
+	h.last = transaction
+	h.trained++
+}
+
+// reset forgets all transactions trained on.
+func (h *historyTracker) reset() {
+	*h = historyTracker{}
+}
+
+// replaced returns whether the history is not the one trained on plus new
+// transactions appended to it.
+func (h *historyTracker) replaced(history []journal.Transaction) bool {
+	if len(history) < h.trained {
+		return true
+	}
+	if h.trained == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(history[0], h.first) || !reflect.DeepEqual(history[h.trained-1], h.last)
+}
+
+// appended returns the transactions appended to the history since it was
+// trained on.
+func (h *historyTracker) appended(history []journal.Transaction) []journal.Transaction {
+	return history[h.trained:]
+}
@@ -0,0 +1,217 @@
+package accountguesser
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+// NaiveBayesAccountGuesser guesses the account of each posting with a naive
+// Bayes classifier over the description tokens, the ammount bucket, the
+// weekday and the accounts already entered. It's trained when
+// created, and must be kept in sync with the history (see Sync), which is
+// not read from the Inputs.
+type NaiveBayesAccountGuesser struct {
+	// classCounts counts the accounts by posting index.
+	classCounts map[int]map[journal.Account]int
+	// featureCounts counts the features by posting index and account.
+	featureCounts map[int]map[journal.Account]map[string]int
+	// featureTotals is the sum of featureCounts by posting index and account.
+	featureTotals map[int]map[journal.Account]int
+	// vocabulary are all the features seen.
+	vocabulary map[string]bool
+	history    historyTracker
+}
+
+var _ AccountGuesser = &NaiveBayesAccountGuesser{}
+
+// Train resets the guesser and trains it from a transaction history.
+func (ag *NaiveBayesAccountGuesser) Train(history []journal.Transaction) {
+	ag.classCounts = map[int]map[journal.Account]int{}
+	ag.featureCounts = map[int]map[journal.Account]map[string]int{}
+	ag.featureTotals = map[int]map[journal.Account]int{}
+	ag.vocabulary = map[string]bool{}
+	ag.history.reset()
+	for _, transaction := range history {
+		ag.Learn(transaction)
+	}
+}
+
+// Learn updates the guesser with a new transaction.
+func (ag *NaiveBayesAccountGuesser) Learn(transaction journal.Transaction) {
+	ag.history.track(transaction)
+	if len(transaction.Posting) == 0 {
+		return
+	}
+	ammount := transaction.Posting[0].Ammount.Quantity
+	for i, posting := range transaction.Posting {
+		account := journal.Account(posting.Account)
+		if ag.classCounts[i] == nil {
+			ag.classCounts[i] = map[journal.Account]int{}
+			ag.featureCounts[i] = map[journal.Account]map[string]int{}
+			ag.featureTotals[i] = map[journal.Account]int{}
+		}
+		if ag.featureCounts[i][account] == nil {
+			ag.featureCounts[i][account] = map[string]int{}
+		}
+		ag.classCounts[i][account]++
+		for _, feature := range features(transaction.Description, ammount, transaction.Date, transaction.Posting[:i]) {
+			ag.featureCounts[i][account][feature]++
+			ag.featureTotals[i][account]++
+			ag.vocabulary[feature] = true
+		}
+	}
+}
+
+// Sync trains the guesser on the transactions appended to the history since
+// the last sync, or on the whole history if it was replaced.
+func (ag *NaiveBayesAccountGuesser) Sync(history []journal.Transaction) {
+	if ag.classCounts == nil || ag.history.replaced(history) {
+		ag.Train(history)
+		return
+	}
+	for _, transaction := range ag.history.appended(history) {
+		ag.Learn(transaction)
+	}
+}
+
+// Rank implements AccountGuesser. The scores are the probabilities of
+// the accounts for the posting being entered. Accounts already entered are
+// not candidates.
+func (ag *NaiveBayesAccountGuesser) Rank(inputs Inputs) []Candidate {
+	index := len(inputs.PostingInputs)
+	classCounts := ag.classCounts[index]
+	if len(classCounts) == 0 {
+		return []Candidate{}
+	}
+
+	description := inputs.Description
+	if description == "" {
+		description = inputs.StatementEntry.Description
+	}
+	// Like the ammount guesser, the first posting is assumed to have the
+	// inverted statement ammount.
+	ammount := inputs.StatementEntry.Ammount.Quantity.Neg()
+	if len(inputs.PostingInputs) > 0 {
+		ammount = inputs.PostingInputs[0].Ammount.Quantity
+	}
+	inputFeatures := []string{}
+	for _, feature := range features(description, ammount, inputs.StatementEntry.Date, inputs.PostingInputs) {
+		// Features never seen carry no information.
+		if ag.vocabulary[feature] {
+			inputFeatures = append(inputFeatures, feature)
+		}
+	}
+
+	entered := map[journal.Account]bool{}
+	for _, posting := range inputs.PostingInputs {
+		entered[journal.Account(posting.Account)] = true
+	}
+	total := 0
+	for _, count := range classCounts {
+		total += count
+	}
+	vocabularySize := float64(len(ag.vocabulary))
+	candidates := []Candidate{}
+	logScores := []float64{}
+	for account, count := range classCounts {
+		if entered[account] {
+			continue
+		}
+		logScore := math.Log(float64(count) / float64(total))
+		featureTotal := float64(ag.featureTotals[index][account])
+		for _, feature := range inputFeatures {
+			featureCount := float64(ag.featureCounts[index][account][feature])
+			logScore += math.Log((featureCount + 1) / (featureTotal + vocabularySize))
+		}
+		candidates = append(candidates, Candidate{Account: account, Scored: true})
+		logScores = append(logScores, logScore)
+	}
+	if len(candidates) == 0 {
+		return candidates
+	}
+
+	// Normalizes the scores into probabilities.
+	maxLogScore := logScores[0]
+	for _, logScore := range logScores {
+		maxLogScore = math.Max(maxLogScore, logScore)
+	}
+	sum := 0.0
+	for i, logScore := range logScores {
+		candidates[i].Score = math.Exp(logScore - maxLogScore)
+		sum += candidates[i].Score
+	}
+	for i := range candidates {
+		candidates[i].Score /= sum
+	}
+	sortCandidates(candidates)
+	return candidates
+}
+
+// Guess implements AccountGuesser.
+func (ag *NaiveBayesAccountGuesser) Guess(inputs Inputs) (acc journal.Account, success bool) {
+	candidates := ag.Rank(inputs)
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return candidates[0].Account, true
+}
+
+// NewNaiveBayesAccountGuesser returns a new NaiveBayesAccountGuesser, trained
+// on a transaction history.
+func NewNaiveBayesAccountGuesser(history []journal.Transaction) (*NaiveBayesAccountGuesser, error) {
+	guesser := &NaiveBayesAccountGuesser{}
+	guesser.Train(history)
+	return guesser, nil
+}
+
+// features returns the features of a transaction for the posting after the
+// `entered` ones.
+func features(description string, ammount decimal.Decimal, date time.Time, entered []journal.Posting) []string {
+	out := []string{}
+	for _, token := range tokenize(description) {
+		out = append(out, "token:"+token)
+	}
+	if !ammount.IsZero() {
+		out = append(out, "ammount:"+ammountBucket(ammount))
+	}
+	if !date.IsZero() {
+		out = append(out, "weekday:"+date.Weekday().String())
+	}
+	for _, posting := range entered {
+		out = append(out, "entered:"+posting.Account)
+	}
+	return out
+}
+
+// tokenize splits a description into lowercase words, ignoring numbers and
+// single characters, which are usually noise (dates, card numbers, etc).
+func tokenize(description string) []string {
+	tokens := []string{}
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if len(word) < 2 || strings.IndexFunc(word, unicode.IsLetter) == -1 {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// ammountBucket returns the sign and order of magnitude of an ammount, e.g.
+// `-2` for ammounts between -10 and -99.99.
+func ammountBucket(ammount decimal.Decimal) string {
+	sign := "+"
+	if ammount.IsNegative() {
+		sign = "-"
+	}
+	digits := len(ammount.Abs().Truncate(0).String())
+	return sign + strconv.Itoa(digits)
+}
@@ -0,0 +1,174 @@
+package accountguesser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/accountguesser"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+func TestNaiveBayesAccountGuesser(t *testing.T) {
+	eur := func(x string) finance.Ammount {
+		return finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+	}
+	monday := time.Date(2023, 10, 30, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2023, 10, 28, 0, 0, 0, 0, time.UTC)
+	transaction := func(description string, date time.Time, ammount string, accounts ...string) journal.Transaction {
+		postings := []journal.Posting{}
+		for i, account := range accounts {
+			posting := journal.Posting{Account: account, Ammount: eur(ammount)}
+			if i > 0 {
+				posting.Ammount = posting.Ammount.InvertSign()
+			}
+			postings = append(postings, posting)
+		}
+		return journal.Transaction{Description: description, Date: date, Posting: postings}
+	}
+	history := []journal.Transaction{
+		transaction("MERCADONA VALENCIA", monday, "-45.10", "assets:bank", "expenses:groceries"),
+		transaction("Mercadona 1234", saturday, "-60", "assets:bank", "expenses:groceries"),
+		transaction("Carrefour market", monday, "-30", "assets:bank", "expenses:groceries"),
+		transaction("Uber trip", saturday, "-12", "assets:card", "expenses:taxi"),
+		transaction("Uber trip 2", monday, "-9.5", "assets:card", "expenses:taxi"),
+		transaction("Salary ACME", monday, "2500", "assets:bank", "income:salary"),
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, guesser *NaiveBayesAccountGuesser)
+	}
+	testcases := []testcase{
+		{
+			name: "No history",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				guesser.Train(nil)
+				_, success := guesser.Guess(Inputs{Description: "Mercadona"})
+				assert.False(t, success)
+				assert.Empty(t, guesser.Rank(Inputs{Description: "Mercadona"}))
+			},
+		},
+		{
+			name: "Guesses from description tokens",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				account, success := guesser.Guess(Inputs{
+					Description:   "mercadona",
+					PostingInputs: []journal.Posting{{Account: "assets:bank", Ammount: eur("-20")}},
+				})
+				assert.True(t, success)
+				assert.Equal(t, journal.Account("expenses:groceries"), account)
+			},
+		},
+		{
+			name: "Guesses from accounts already entered",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				account, success := guesser.Guess(Inputs{
+					Description:   "Something",
+					PostingInputs: []journal.Posting{{Account: "assets:card", Ammount: eur("-10")}},
+				})
+				assert.True(t, success)
+				assert.Equal(t, journal.Account("expenses:taxi"), account)
+			},
+		},
+		{
+			name: "Guesses first posting from statement entry",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				account, success := guesser.Guess(Inputs{
+					StatementEntry: finance.StatementEntry{
+						Description: "UBER *TRIP",
+						Date:        saturday,
+						Ammount:     eur("12"),
+					},
+				})
+				assert.True(t, success)
+				assert.Equal(t, journal.Account("assets:card"), account)
+			},
+		},
+		{
+			name: "Ranks candidates with scores",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				candidates := guesser.Rank(Inputs{
+					Description:   "Salary",
+					PostingInputs: []journal.Posting{{Account: "assets:bank", Ammount: eur("2500")}},
+				})
+				assert.Len(t, candidates, 3)
+				assert.Equal(t, journal.Account("income:salary"), candidates[0].Account)
+				total := 0.0
+				for i, candidate := range candidates {
+					total += candidate.Score
+					if i > 0 {
+						assert.GreaterOrEqual(t, candidates[i-1].Score, candidate.Score)
+					}
+				}
+				assert.InDelta(t, 1, total, 0.0001)
+			},
+		},
+		{
+			name: "Does not suggest accounts already entered",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				candidates := guesser.Rank(Inputs{
+					Description: "mercadona",
+					PostingInputs: []journal.Posting{
+						{Account: "assets:bank", Ammount: eur("-20")},
+						{Account: "expenses:groceries", Ammount: eur("20")},
+					},
+				})
+				assert.Empty(t, candidates)
+			},
+		},
+		{
+			name: "Learns transactions appended to the history",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				inputs := Inputs{
+					Description:   "Gym",
+					PostingInputs: []journal.Posting{{Account: "assets:bank", Ammount: eur("-40")}},
+				}
+				account, _ := guesser.Guess(inputs)
+				assert.NotEqual(t, journal.Account("expenses:gym"), account)
+				gym := transaction("Gym monthly fee", monday, "-40", "assets:bank", "expenses:gym")
+				guesser.Sync(append(append([]journal.Transaction{}, history...), gym, gym))
+				account, _ = guesser.Guess(inputs)
+				assert.Equal(t, journal.Account("expenses:gym"), account)
+			},
+		},
+		{
+			name: "Retrains when the history is replaced",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				inputs := Inputs{
+					Description:   "mercadona",
+					PostingInputs: []journal.Posting{{Account: "assets:bank", Ammount: eur("-20")}},
+				}
+				account, _ := guesser.Guess(inputs)
+				assert.Equal(t, journal.Account("expenses:groceries"), account)
+				other := transaction("Mercadona", monday, "-20", "assets:bank", "expenses:food")
+				guesser.Sync([]journal.Transaction{other})
+				candidates := guesser.Rank(inputs)
+				assert.Equal(t, []Candidate{{Account: "expenses:food", Score: 1, Scored: true}}, candidates)
+			},
+		},
+		{
+			name: "Learns explicitly",
+			run: func(t *testing.T, guesser *NaiveBayesAccountGuesser) {
+				gym := transaction("Gym monthly fee", monday, "-40", "assets:bank", "expenses:gym")
+				guesser.Learn(gym)
+				guesser.Learn(gym)
+				// The history matches what was learned, so nothing is retrained.
+				guesser.Sync(append(append([]journal.Transaction{}, history...), gym, gym))
+				account, _ := guesser.Guess(Inputs{
+					Description:   "Gym",
+					PostingInputs: []journal.Posting{{Account: "assets:bank", Ammount: eur("-40")}},
+				})
+				assert.Equal(t, journal.Account("expenses:gym"), account)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			guesser, err := NewNaiveBayesAccountGuesser(history)
+			assert.Nil(t, err)
+			tc.run(t, guesser)
+		})
+	}
+}
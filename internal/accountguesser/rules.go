@@ -0,0 +1,234 @@
+package accountguesser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/shopspring/decimal"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+// Sign restricts the sign of the ammount matched by a Rule.
+type Sign string
+
+const (
+	AnySign      Sign = ""
+	PositiveSign Sign = "+"
+	NegativeSign Sign = "-"
+)
+
+// AnyPosting is the posting index of a Rule that may suggest its account for
+// any posting, as long as the account was not entered yet.
+const AnyPosting = -1
+
+// Rule suggests an account for a posting when all of its conditions match.
+// Nil (or empty) conditions always match.
+type Rule struct {
+	// Description matches the description the user has inputted.
+	Description *regexp.Regexp
+	// StatementDescription matches the description of the statement entry,
+	// or its original description (before description rules).
+	StatementDescription *regexp.Regexp
+	// MinAmmount and MaxAmmount are the range of the absolute value of the
+	// ammount. The ammount is the one from the statement entry or, if there
+	// is none, the one of the first posting.
+	MinAmmount *decimal.Decimal
+	MaxAmmount *decimal.Decimal
+	// Sign is the sign of the ammount.
+	Sign Sign
+	// EnteredAccount matches any of the accounts already entered.
+	EnteredAccount *regexp.Regexp
+	// Posting is the index of the posting the account is for, or AnyPosting.
+	Posting int
+	// Account is the suggested account.
+	Account journal.Account
+}
+
+// matches returns whether the rule suggests its account for the inputs.
+func (r Rule) matches(inputs Inputs) bool {
+	if r.Posting == AnyPosting {
+		for _, posting := range inputs.PostingInputs {
+			if posting.Account == string(r.Account) {
+				return false
+			}
+		}
+	} else if r.Posting != len(inputs.PostingInputs) {
+		return false
+	}
+	if r.Description != nil && !r.Description.MatchString(inputs.Description) {
+		return false
+	}
+	if r.StatementDescription != nil && !r.matchesStatementDescription(inputs.StatementEntry) {
+		return false
+	}
+	if r.EnteredAccount != nil && !r.matchesEnteredAccount(inputs.PostingInputs) {
+		return false
+	}
+	return r.matchesAmmount(inputs)
+}
+
+func (r Rule) matchesStatementDescription(entry finance.StatementEntry) bool {
+	if entry.Description != "" && r.StatementDescription.MatchString(entry.Description) {
+		return true
+	}
+	return entry.OriginalDescription != "" && r.StatementDescription.MatchString(entry.OriginalDescription)
+}
+
+func (r Rule) matchesEnteredAccount(postings []journal.Posting) bool {
+	for _, posting := range postings {
+		if r.EnteredAccount.MatchString(posting.Account) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesAmmount(inputs Inputs) bool {
+	if r.MinAmmount == nil && r.MaxAmmount == nil && r.Sign == AnySign {
+		return true
+	}
+	quantity := inputs.StatementEntry.Ammount.Quantity
+	if quantity.IsZero() {
+		if len(inputs.PostingInputs) == 0 {
+			return false
+		}
+		quantity = inputs.PostingInputs[0].Ammount.Quantity
+	}
+	switch r.Sign {
+	case PositiveSign:
+		if !quantity.IsPositive() {
+			return false
+		}
+	case NegativeSign:
+		if !quantity.IsNegative() {
+			return false
+		}
+	}
+	if r.MinAmmount != nil && quantity.Abs().LessThan(*r.MinAmmount) {
+		return false
+	}
+	if r.MaxAmmount != nil && quantity.Abs().GreaterThan(*r.MaxAmmount) {
+		return false
+	}
+	return true
+}
+
+// RulesAccountGuesser guesses the account from user defined rules. The first
+// matching rule wins.
+type RulesAccountGuesser struct {
+	rules []Rule
+}
+
+var _ AccountGuesser = &RulesAccountGuesser{}
+
+// Guess implements AccountGuesser.
+func (ag *RulesAccountGuesser) Guess(inputs Inputs) (acc journal.Account, success bool) {
+	for _, rule := range ag.rules {
+		if rule.matches(inputs) {
+			return rule.Account, true
+		}
+	}
+	return "", false
+}
+
+// Rank implements AccountGuesser.
+func (ag *RulesAccountGuesser) Rank(inputs Inputs) []Candidate {
+	return rankGuess(ag.Guess(inputs))
+}
+
+// NewRulesAccountGuesser returns a new RulesAccountGuesser using the rules, in
+// order.
+func NewRulesAccountGuesser(rules []Rule) (*RulesAccountGuesser, error) {
+	return &RulesAccountGuesser{rules: rules}, nil
+}
+
+// ruleConfig is a Rule as written in a rules file.
+type ruleConfig struct {
+	Description          string           `json:"description"`
+	StatementDescription string           `json:"statementDescription"`
+	MinAmmount           *decimal.Decimal `json:"minAmmount"`
+	MaxAmmount           *decimal.Decimal `json:"maxAmmount"`
+	Sign                 Sign             `json:"sign"`
+	EnteredAccount       string           `json:"enteredAccount"`
+	Posting              *int             `json:"posting"`
+	Account              string           `json:"account"`
+}
+
+// ParseRules parses the rules from the content of a (json) rules file.
+// Patterns are case insensitive.
+func ParseRules(content []byte) ([]Rule, error) {
+	var configs []ruleConfig
+	if err := json.Unmarshal(content, &configs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal account rules: %w", err)
+	}
+	rules := make([]Rule, 0, len(configs))
+	for i, config := range configs {
+		rule, err := parseRule(config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account rule %d: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseRule(config ruleConfig) (Rule, error) {
+	if config.Account == "" {
+		return Rule{}, fmt.Errorf("missing account")
+	}
+	if config.Sign != AnySign && config.Sign != PositiveSign && config.Sign != NegativeSign {
+		return Rule{}, fmt.Errorf("invalid sign: %s", config.Sign)
+	}
+	rule := Rule{
+		MinAmmount: config.MinAmmount,
+		MaxAmmount: config.MaxAmmount,
+		Sign:       config.Sign,
+		Posting:    AnyPosting,
+		Account:    journal.Account(config.Account),
+	}
+	if config.Posting != nil {
+		if *config.Posting < 0 {
+			return Rule{}, fmt.Errorf("invalid posting: %d", *config.Posting)
+		}
+		rule.Posting = *config.Posting
+	}
+	var err error
+	if rule.Description, err = compilePattern(config.Description); err != nil {
+		return Rule{}, err
+	}
+	if rule.StatementDescription, err = compilePattern(config.StatementDescription); err != nil {
+		return Rule{}, err
+	}
+	if rule.EnteredAccount, err = compilePattern(config.EnteredAccount); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// compilePattern compiles a case insensitive pattern. Empty patterns are nil.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	return compiled, nil
+}
+
+// LoadRules loads the rules from a rules file. A missing file has no rules.
+func LoadRules(file string) ([]Rule, error) {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account rules file %s: %w", file, err)
+	}
+	return ParseRules(content)
+}
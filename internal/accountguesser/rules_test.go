@@ -0,0 +1,165 @@
+package accountguesser_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/accountguesser"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+)
+
+func TestRulesAccountGuesser(t *testing.T) {
+	rules, err := ParseRules([]byte(`[
+		{"statementDescription": "UBER|CABIFY", "posting": 1, "account": "expenses:urban-transportation:taxi"},
+		{"description": "^salary$", "sign": "+", "account": "income:salary"},
+		{"enteredAccount": "^assets:card", "minAmmount": 1000, "account": "expenses:big"},
+		{"enteredAccount": "^assets:card", "maxAmmount": "10.5", "account": "expenses:small"}
+	]`))
+	assert.Nil(t, err)
+	eur := func(x string) finance.Ammount {
+		return finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+	}
+	type testcase struct {
+		name     string
+		inputs   Inputs
+		success  bool
+		expected journal.Account
+	}
+	testcases := []testcase{
+		{
+			name:    "no match",
+			inputs:  Inputs{Description: "Supermarket"},
+			success: false,
+		},
+		{
+			name: "matches statement description on posting index",
+			inputs: Inputs{
+				StatementEntry: finance.StatementEntry{Description: "Uber trip"},
+				PostingInputs:  []journal.Posting{{Account: "assets:bank"}},
+			},
+			success:  true,
+			expected: "expenses:urban-transportation:taxi",
+		},
+		{
+			name: "matches original statement description",
+			inputs: Inputs{
+				StatementEntry: finance.StatementEntry{Description: "Taxi", OriginalDescription: "CABIFY 1234"},
+				PostingInputs:  []journal.Posting{{Account: "assets:bank"}},
+			},
+			success:  true,
+			expected: "expenses:urban-transportation:taxi",
+		},
+		{
+			name:    "does not match other posting index",
+			inputs:  Inputs{StatementEntry: finance.StatementEntry{Description: "Uber trip"}},
+			success: false,
+		},
+		{
+			name: "matches description and sign of statement ammount",
+			inputs: Inputs{
+				Description:    "Salary",
+				StatementEntry: finance.StatementEntry{Ammount: eur("1000")},
+			},
+			success:  true,
+			expected: "income:salary",
+		},
+		{
+			name: "does not match other sign",
+			inputs: Inputs{
+				Description:    "Salary",
+				StatementEntry: finance.StatementEntry{Ammount: eur("-1000")},
+			},
+			success: false,
+		},
+		{
+			name:    "does not match sign without ammount",
+			inputs:  Inputs{Description: "Salary"},
+			success: false,
+		},
+		{
+			name: "does not suggest an account already entered",
+			inputs: Inputs{
+				Description:    "Salary",
+				StatementEntry: finance.StatementEntry{Ammount: eur("1000")},
+				PostingInputs:  []journal.Posting{{Account: "income:salary", Ammount: eur("-1000")}},
+			},
+			success: false,
+		},
+		{
+			name: "matches entered account and minimum ammount of first posting",
+			inputs: Inputs{
+				PostingInputs: []journal.Posting{{Account: "assets:card", Ammount: eur("-1500")}},
+			},
+			success:  true,
+			expected: "expenses:big",
+		},
+		{
+			name: "matches entered account and maximum ammount of first posting",
+			inputs: Inputs{
+				PostingInputs: []journal.Posting{{Account: "assets:card", Ammount: eur("-10.5")}},
+			},
+			success:  true,
+			expected: "expenses:small",
+		},
+		{
+			name: "does not match ammount out of range",
+			inputs: Inputs{
+				PostingInputs: []journal.Posting{{Account: "assets:card", Ammount: eur("-20")}},
+			},
+			success: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			guesser, err := NewRulesAccountGuesser(rules)
+			assert.Nil(t, err)
+			account, success := guesser.Guess(tc.inputs)
+			assert.Equal(t, tc.success, success)
+			assert.Equal(t, tc.expected, account)
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	type testcase struct {
+		name          string
+		content       string
+		expectedError string
+	}
+	testcases := []testcase{
+		{name: "invalid json", content: `{`, expectedError: "failed to unmarshal account rules"},
+		{name: "missing account", content: `[{"description": "foo"}]`, expectedError: "invalid account rule 0: missing account"},
+		{name: "invalid sign", content: `[{"sign": "x", "account": "a"}]`, expectedError: "invalid sign: x"},
+		{name: "invalid posting", content: `[{"posting": -2, "account": "a"}]`, expectedError: "invalid posting: -2"},
+		{name: "invalid pattern", content: `[{"description": "(foo", "account": "a"}]`, expectedError: "invalid pattern (foo"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tc.content))
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Run("Missing file", func(t *testing.T) {
+		rules, err := LoadRules(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Nil(t, err)
+		assert.Empty(t, rules)
+	})
+
+	t.Run("Loads rules", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "account-rules.json")
+		err := os.WriteFile(file, []byte(`[{"description": "foo", "account": "expenses:foo"}]`), 0644)
+		assert.Nil(t, err)
+		rules, err := LoadRules(file)
+		assert.Nil(t, err)
+		assert.Len(t, rules, 1)
+		assert.Equal(t, journal.Account("expenses:foo"), rules[0].Account)
+		assert.Equal(t, AnyPosting, rules[0].Posting)
+	})
+}
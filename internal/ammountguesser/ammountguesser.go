@@ -27,6 +27,14 @@ type Inputs struct {
 
 	// MatchingTransactions are the transactions that match the current user input.
 	MatchingTransactions []journal.Transaction
+
+	// JournalDefaultCommodity is the commodity declared as default in the
+	// journal (`D` directive), if any.
+	JournalDefaultCommodity string
+
+	// AccountCommodity is the commodity most used with the account of the
+	// posting being entered, if any.
+	AccountCommodity string
 }
 
 // IAmmountGuesser is a strategy for guessing the ammount an user may want for an journal entry.
@@ -35,33 +43,50 @@ type IAmmountGuesser interface {
 }
 
 // AmmountGuesser is the default implementation of IAmmountGuesser.
-type AmmountGuesser struct{}
+type AmmountGuesser struct {
+	// defaultCommodity is the configured commodity for ammounts without one.
+	defaultCommodity string
+}
 
 // Guess implements IAmmountGuesser.
-func (*AmmountGuesser) Guess(inputs Inputs) (guess finance.Ammount, success bool) {
-	// If user entered an ammount, use it
-	if ammountFromUserInput, err := userinput.TextToAmmount(inputs.UserInput); err == nil {
+func (ag *AmmountGuesser) Guess(inputs Inputs) (guess finance.Ammount, success bool) {
+	// Calculate balance left from postings
+	nonEmptyPostingData := selectNonEmptyPostingData(inputs.PostingsData)
+	postings, _ := userinput.PostingsFromData(nonEmptyPostingData)
+	balance := journal.PostingsBalance(postings)
+	pendingBalance := balance.SingleCommodity() && !balance.IsZero()
+
+	// If user entered a shortcut for the pending balance, use it. Shares
+	// (e.g. `1/3`) take precedence over the expression they also are.
+	if pendingBalance {
+		if guess, found := pendingBalanceShortcut(inputs.UserInput, balance.Ammounts()[0]); found {
+			return guess, true
+		}
+	}
+
+	// If user entered an ammount (or an expression), use it
+	if ammountFromUserInput, err := userinput.TextToAmmountExpression(inputs.UserInput); err == nil {
 		if ammountFromUserInput.Commodity == "" {
-			ammountFromUserInput.Commodity = DefaultCommodity
+			ammountFromUserInput.Commodity = ag.commodity(inputs)
 		}
 		return ammountFromUserInput, true
 	}
 
-	// Calculate balance left from postings
-	nonEmptyPostingData := selectNonEmptyPostingData(inputs.PostingsData)
-	postings, _ := userinput.PostingsFromData(nonEmptyPostingData)
-	balance := journal.PostingsBalance(postings)
-	if balance.SingleCommodity() && !balance.IsZero() {
-		// If user entered a fraction, use it
-		if fraction, err := userinput.TextToFraction(inputs.UserInput); err == nil {
-			return balance.Ammounts()[0].Mul(fraction).Round(2).InvertSign(), true
+	if pendingBalance {
+		// If the statement entry is split, use the split for this posting
+		if split, found := statementEntrySplit(inputs.StatementEntry, len(nonEmptyPostingData)); found {
+			return split.Ammount, true
 		}
 		return balance.Ammounts()[0].InvertSign(), true
 	}
 
 	// If we have a statement entry, use it
 	if inputs.StatementEntry.Ammount.Quantity.Abs().GreaterThan(decimal.Zero) {
-		return inputs.StatementEntry.Ammount.InvertSign(), true
+		ammount := inputs.StatementEntry.Ammount.InvertSign()
+		if ammount.Commodity == "" {
+			ammount.Commodity = ag.commodity(inputs)
+		}
+		return ammount, true
 	}
 
 	// If we have a matching transaction, use it.
@@ -69,19 +94,58 @@ func (*AmmountGuesser) Guess(inputs Inputs) (guess finance.Ammount, success bool
 		return inputs.MatchingTransactions[0].Posting[0].Ammount, true
 	}
 
-	return DefaultGuess, true
+	return finance.Ammount{}, false
+}
+
+// commodity returns the commodity for an ammount entered without one: the
+// configured default, the journal default or the one most used with the
+// account, in this order. Empty if none is known.
+func (ag *AmmountGuesser) commodity(inputs Inputs) string {
+	if ag.defaultCommodity != "" {
+		return ag.defaultCommodity
+	}
+	if inputs.JournalDefaultCommodity != "" {
+		return inputs.JournalDefaultCommodity
+	}
+	return inputs.AccountCommodity
 }
 
 var _ IAmmountGuesser = &AmmountGuesser{}
 
-func New() *AmmountGuesser { return &AmmountGuesser{} }
+// New returns a new AmmountGuesser. `defaultCommodity` is the commodity for
+// ammounts entered without one, or empty to use the one from the inputs.
+func New(defaultCommodity string) *AmmountGuesser {
+	return &AmmountGuesser{defaultCommodity: defaultCommodity}
+}
 
-var DefaultCommodity string = "EUR"
+// pendingBalanceShortcut returns the ammount for a text that is a shortcut
+// acting on the pending balance: a share (`1/3`), a percentage (`30%`), the
+// full balance (`rest`) or the first share of an even split (`=3`).
+func pendingBalanceShortcut(text string, balance finance.Ammount) (finance.Ammount, bool) {
+	if fraction, err := userinput.TextToShare(text); err == nil {
+		return balance.Mul(fraction).Round(2).InvertSign(), true
+	}
+	if percentage, err := userinput.TextToPercentage(text); err == nil {
+		return balance.Mul(percentage).Round(2).InvertSign(), true
+	}
+	if text == userinput.RestText {
+		return balance.InvertSign(), true
+	}
+	if n, err := userinput.TextToSplit(text); err == nil {
+		return balance.InvertSign().Split(n)[0], true
+	}
+	return finance.Ammount{}, false
+}
 
-// TODO Get rid of this!
-var DefaultGuess = finance.Ammount{
-	Commodity: "EUR",
-	Quantity:  decimal.New(1220, -2),
+// statementEntrySplit returns the split of a statement entry that corresponds
+// to the posting with index `postingIndex`. The first posting is assumed to be
+// the one for the statement entry account, so splits start on the second one.
+func statementEntrySplit(entry finance.StatementEntry, postingIndex int) (finance.StatementEntrySplit, bool) {
+	splitIndex := postingIndex - 1
+	if splitIndex < 0 || splitIndex >= len(entry.Splits) {
+		return finance.StatementEntrySplit{}, false
+	}
+	return entry.Splits[splitIndex], true
 }
 
 func selectNonEmptyPostingData(postingsData []*state.PostingData) []*state.PostingData {
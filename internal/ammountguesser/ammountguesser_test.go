@@ -18,11 +18,12 @@ var anotherAmmount = finance.Ammount{Commodity: "USD", Quantity: decimal.New(992
 
 func TestAmmountGuesser(t *testing.T) {
 	type testcase struct {
-		name      string
-		inputs    Inputs
-		setupFunc func(tc *testcase)
-		guess     finance.Ammount
-		success   bool
+		name             string
+		defaultCommodity string
+		inputs           Inputs
+		setupFunc        func(tc *testcase)
+		guess            finance.Ammount
+		success          bool
 	}
 	var testcases = []testcase{
 		{
@@ -32,8 +33,49 @@ func TestAmmountGuesser(t *testing.T) {
 			success: true,
 		},
 		{
-			name:    "Guesses from user input without currency using default",
+			name:             "Guesses from user input without currency using configured default",
+			defaultCommodity: "EUR",
+			inputs:           Inputs{UserInput: "12.21", JournalDefaultCommodity: "BRL", AccountCommodity: "USD"},
+			guess:            anAmmount,
+			success:          true,
+		},
+		{
+			name:    "Guesses from user input without currency using journal default",
+			inputs:  Inputs{UserInput: "12.22", JournalDefaultCommodity: "BRL", AccountCommodity: "USD"},
+			guess:   anAmmountBRL,
+			success: true,
+		},
+		{
+			name:    "Guesses from user input without currency using account commodity",
+			inputs:  Inputs{UserInput: "12.21", AccountCommodity: "EUR"},
+			guess:   anAmmount,
+			success: true,
+		},
+		{
+			name:    "Guesses from user input without currency and no commodity known",
 			inputs:  Inputs{UserInput: "12.21"},
+			guess:   finance.Ammount{Quantity: decimal.New(1221, -2)},
+			success: true,
+		},
+		{
+			name:    "Does not guess without any input",
+			inputs:  Inputs{JournalDefaultCommodity: "BRL"},
+			guess:   finance.Ammount{},
+			success: false,
+		},
+		{
+			name:    "Guesses from user input expression",
+			inputs:  Inputs{UserInput: "BRL 2.22+5*2"},
+			guess:   anAmmountBRL,
+			success: true,
+		},
+		{
+			name: "Guesses from user input expression with pending balance",
+			setupFunc: func(tc *testcase) {
+				postingData := tu.PostingData_1(t)
+				tc.inputs.PostingsData = []*state.PostingData{&postingData}
+				tc.inputs.UserInput = "EUR 24.42/2"
+			},
 			guess:   anAmmount,
 			success: true,
 		},
@@ -76,6 +118,15 @@ func TestAmmountGuesser(t *testing.T) {
 			guess:   anotherAmmount.InvertSign(),
 			success: true,
 		},
+		{
+			name: "Guess from loaded statement entry without commodity",
+			setupFunc: func(tc *testcase) {
+				tc.inputs.StatementEntry = finance.StatementEntry{Ammount: finance.Ammount{Quantity: decimal.New(-1222, -2)}}
+				tc.inputs.AccountCommodity = "BRL"
+			},
+			guess:   anAmmountBRL,
+			success: true,
+		},
 		{
 			name: "Guess from pending balance",
 			setupFunc: func(tc *testcase) {
@@ -95,6 +146,24 @@ func TestAmmountGuesser(t *testing.T) {
 			guess:   anAmmount.InvertSign(),
 			success: true,
 		},
+		{
+			name: "Guess from statement entry split",
+			setupFunc: func(tc *testcase) {
+				postingData := state.NewPostingData()
+				postingData.Account.Set("ACC1")
+				postingData.Ammount.Set(anAmmount.InvertSign())
+				tc.inputs.PostingsData = []*state.PostingData{postingData}
+				tc.inputs.StatementEntry = finance.StatementEntry{
+					Ammount: anAmmount,
+					Splits: []finance.StatementEntrySplit{
+						{Ammount: finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1000, -2)}},
+						{Ammount: finance.Ammount{Commodity: "EUR", Quantity: decimal.New(221, -2)}},
+					},
+				}
+			},
+			guess:   finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1000, -2)},
+			success: true,
+		},
 		{
 			name: "Guess from user-inputted fraction",
 			setupFunc: func(tc *testcase) {
@@ -115,13 +184,60 @@ func TestAmmountGuesser(t *testing.T) {
 			guess:   tu.Posting_1(t).Ammount.Div(decimal.New(2, 0)).InvertSign(),
 			success: true,
 		},
+		{
+			name: "Guess from user-inputted division bigger than one",
+			setupFunc: func(tc *testcase) {
+				postingData := tu.PostingData_1(t)
+				tc.inputs.PostingsData = []*state.PostingData{&postingData}
+				tc.inputs.UserInput = "100/3"
+				tc.inputs.JournalDefaultCommodity = "EUR"
+			},
+			guess:   finance.Ammount{Commodity: "EUR", Quantity: decimal.New(3333, -2)},
+			success: true,
+		},
+		{
+			name: "Guess from user-inputted percentage",
+			setupFunc: func(tc *testcase) {
+				postingData := tu.PostingData_1(t)
+				tc.inputs.PostingsData = []*state.PostingData{&postingData}
+				tc.inputs.UserInput = "50%"
+			},
+			guess:   tu.Posting_1(t).Ammount.Div(decimal.New(2, 0)).InvertSign(),
+			success: true,
+		},
+		{
+			name: "Guess from user-inputted rest",
+			setupFunc: func(tc *testcase) {
+				postingData := tu.PostingData_1(t)
+				tc.inputs.PostingsData = []*state.PostingData{&postingData}
+				tc.inputs.StatementEntry = finance.StatementEntry{
+					Ammount: anAmmount,
+					Splits:  []finance.StatementEntrySplit{{Ammount: anotherAmmount}},
+				}
+				tc.inputs.UserInput = "rest"
+			},
+			guess:   tu.Posting_1(t).Ammount.InvertSign(),
+			success: true,
+		},
+		{
+			name: "Guess from user-inputted even split",
+			setupFunc: func(tc *testcase) {
+				postingData := state.NewPostingData()
+				postingData.Account.Set("ACC1")
+				postingData.Ammount.Set(finance.Ammount{Commodity: "EUR", Quantity: decimal.New(100, 0)})
+				tc.inputs.PostingsData = []*state.PostingData{postingData}
+				tc.inputs.UserInput = "=3"
+			},
+			guess:   finance.Ammount{Commodity: "EUR", Quantity: decimal.New(-3334, -2)},
+			success: true,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.setupFunc != nil {
 				tc.setupFunc(&tc)
 			}
-			guesser := AmmountGuesser{}
+			guesser := New(tc.defaultCommodity)
 
 			guess, success := guesser.Guess(tc.inputs)
 			assert.True(t, guess.Equal(tc.guess))
@@ -10,6 +10,7 @@ import (
 	"github.com/vitorqb/addledger/internal/ammountguesser"
 	"github.com/vitorqb/addledger/internal/dateguesser"
 	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/statementprogress"
 	"github.com/vitorqb/addledger/internal/transactionmatcher"
 	"github.com/vitorqb/addledger/internal/userinput"
 )
@@ -70,11 +71,19 @@ func LinkAmmountGuesser(state *statemod.State, guesser ammountguesser.IAmmountGu
 		defer func() { busy = false }()
 
 		currentStatementEntry, _ := state.CurrentStatementEntry()
+		accountCommodity := ""
+		if posting, found := state.Transaction.Postings.Last(); found {
+			if account, found := posting.Account.Get(); found {
+				accountCommodity, _ = state.JournalMetadata.AccountCommodity(account)
+			}
+		}
 		inputs := ammountguesser.Inputs{
-			UserInput:            state.InputMetadata.GetPostingAmmountText(),
-			PostingsData:         state.Transaction.Postings.Get(),
-			StatementEntry:       currentStatementEntry,
-			MatchingTransactions: state.InputMetadata.MatchingTransactions(),
+			UserInput:               state.InputMetadata.GetPostingAmmountText(),
+			PostingsData:            state.Transaction.Postings.Get(),
+			StatementEntry:          currentStatementEntry,
+			MatchingTransactions:    state.InputMetadata.MatchingTransactions(),
+			JournalDefaultCommodity: state.JournalMetadata.DefaultCommodity(),
+			AccountCommodity:        accountCommodity,
 		}
 		guess, success := guesser.Guess(inputs)
 		if !success {
@@ -107,12 +116,13 @@ func LinkAccountGuesser(state *statemod.State, guesser accountguesser.AccountGue
 			PostingInputs:        completePosting,
 			TransactionHistory:   transactionHist,
 		}
-		acc, success := guesser.Guess(inputs)
-		if !success {
+		candidates := guesser.Rank(inputs)
+		state.InputMetadata.SetPostingAccountCandidates(candidates)
+		if len(candidates) == 0 {
 			state.InputMetadata.ClearPostingAccountGuess()
 			return
 		}
-		state.InputMetadata.SetPostingAccountGuess(acc)
+		state.InputMetadata.SetPostingAccountGuess(candidates[0].Account)
 	})
 }
 
@@ -126,13 +136,42 @@ func LinkDateGuesser(state *statemod.State, guesser dateguesser.IDateGuesser) {
 		busy = true
 		defer func() { busy = false }()
 
-		dateText := state.InputMetadata.GetDateText()
 		statementEntry, _ := state.CurrentStatementEntry()
-		dateGuess, success := guesser.Guess(dateText, statementEntry)
-		if success {
-			state.InputMetadata.SetDateGuess(dateGuess)
+		inputs := dateguesser.Inputs{
+			UserInput:      state.InputMetadata.GetDateText(),
+			StatementEntry: statementEntry,
+		}
+		if transactions := state.JournalMetadata.Transactions(); len(transactions) > 0 {
+			inputs.LastTransactionDate = transactions[len(transactions)-1].Date
+		}
+		interpretation, err := guesser.Interpret(inputs)
+		if err == nil {
+			state.InputMetadata.SetDateInterpretation(interpretation.Description)
+			state.InputMetadata.SetDateGuess(interpretation.Date)
 			return
 		}
+		state.InputMetadata.SetDateInterpretation(err.Error())
 		state.InputMetadata.ClearDateGuess()
 	})
 }
+
+// LinkStatementProgressStore saves the progress on each loaded statement
+// every time the statement entries change. Nothing is saved while there is a
+// resumable progress the user has not yet decided on.
+func LinkStatementProgressStore(state *statemod.State, store statementprogress.IStore) {
+	savedVersion := state.StatementVersion()
+	state.AddOnChangeHook(func() {
+		if state.StatementVersion() == savedVersion {
+			return
+		}
+		if _, pending := state.ResumableStatementProgress(); pending {
+			return
+		}
+		savedVersion = state.StatementVersion()
+		for _, source := range state.StatementSources() {
+			if err := store.Save(source.Key, state.StatementProgress(source.Name)); err != nil {
+				logrus.WithError(err).Warn("Failed to save statement progress")
+			}
+		}
+	})
+}
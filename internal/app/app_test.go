@@ -1,6 +1,7 @@
 package app_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/vitorqb/addledger/internal/accountguesser"
 	"github.com/vitorqb/addledger/internal/ammountguesser"
 	. "github.com/vitorqb/addledger/internal/app"
+	"github.com/vitorqb/addledger/internal/dateguesser"
 	"github.com/vitorqb/addledger/internal/finance"
 	"github.com/vitorqb/addledger/internal/journal"
 	statemod "github.com/vitorqb/addledger/internal/state"
@@ -19,6 +21,7 @@ import (
 	accountguesser_mock "github.com/vitorqb/addledger/mocks/accountguesser"
 	ammountguesser_mock "github.com/vitorqb/addledger/mocks/ammountguesser"
 	. "github.com/vitorqb/addledger/mocks/dateguesser"
+	. "github.com/vitorqb/addledger/mocks/statementprogress"
 	. "github.com/vitorqb/addledger/mocks/transactionmatcher"
 )
 
@@ -112,11 +115,18 @@ func TestLinkAmmountGuesser(t *testing.T) {
 		state.InputMetadata.SetMatchingTransactions(matchingTransactions)
 		state.InputMetadata.SetPostingAmmountText(userInput)
 		newPosting := statemod.NewPostingData()
+		newPosting.Account.Set("assets:bank")
 		state.Transaction.Postings.Append(newPosting)
 		state.SetStatementEntries([]finance.StatementEntry{statementEntry})
+		state.JournalMetadata.SetDefaultCommodity("BRL")
+		state.JournalMetadata.SetTransactions([]journal.Transaction{{
+			Posting: []journal.Posting{{Account: "assets:bank", Ammount: finance.Ammount{Commodity: "USD"}}},
+		}})
 		// Assertions & behavior for engine
 		guesser.EXPECT().Guess(gomock.Any()).DoAndReturn(func(inputs ammountguesser.Inputs) (finance.Ammount, bool) {
 			assert.Equal(t, userInput, inputs.UserInput)
+			assert.Equal(t, "BRL", inputs.JournalDefaultCommodity)
+			assert.Equal(t, "USD", inputs.AccountCommodity)
 			assert.Equal(t, statementEntry, inputs.StatementEntry)
 			assert.Equal(t, matchingTransactions, inputs.MatchingTransactions)
 			assert.Equal(t, state.Transaction.Postings.Get(), inputs.PostingsData)
@@ -167,7 +177,8 @@ func TestLinkAccountGuesser(t *testing.T) {
 		state.JournalMetadata.SetTransactions(transationHistory)
 
 		// The expected call to guesser
-		guesser.EXPECT().Guess(inputs).Return(journal.Account(account), true)
+		candidates := []accountguesser.Candidate{{Account: account, Score: 0.8, Scored: true}, {Account: "ACC2", Score: 0.1, Scored: true}}
+		guesser.EXPECT().Rank(inputs).Return(candidates)
 
 		// Links
 		LinkAccountGuesser(state, guesser)
@@ -175,9 +186,10 @@ func TestLinkAccountGuesser(t *testing.T) {
 		// Forces state hooks to run
 		state.SetPhase(statemod.InputPostingAccount)
 
-		// Ensures state is updated w guess
+		// Ensures state is updated w guess and candidates
 		actualGuess, _ := state.InputMetadata.GetPostingAccountGuess()
 		assert.Equal(t, account, actualGuess)
+		assert.Equal(t, candidates, state.InputMetadata.PostingAccountCandidates())
 	})
 
 	t.Run("Clears guess when no guess", func(t *testing.T) {
@@ -190,7 +202,7 @@ func TestLinkAccountGuesser(t *testing.T) {
 		state.InputMetadata.SetPostingAccountGuess(account)
 
 		// The expected call to guesser
-		guesser.EXPECT().Guess(gomock.Any()).Return(journal.Account(""), false)
+		guesser.EXPECT().Rank(gomock.Any()).Return([]accountguesser.Candidate{})
 
 		// Links
 		LinkAccountGuesser(state, guesser)
@@ -220,7 +232,7 @@ func TestLinkDateGuesser(t *testing.T) {
 			name: "Updates guess in state from new date input",
 			run: func(t *testing.T, state *statemod.State, guesser *MockIDateGuesser) {
 				// Expected guesser call
-				guesser.EXPECT().Guess(aDateStr, sEntry).Return(aDate, true)
+				guesser.EXPECT().Interpret(dateguesser.Inputs{UserInput: aDateStr, StatementEntry: sEntry}).Return(dateguesser.Interpretation{Date: aDate, Description: "full date"}, nil)
 
 				// Link
 				LinkDateGuesser(state, guesser)
@@ -231,13 +243,14 @@ func TestLinkDateGuesser(t *testing.T) {
 				// Ensure guess was set
 				actualGuess, _ := state.InputMetadata.GetDateGuess()
 				assert.Equal(t, aDate, actualGuess)
+				assert.Equal(t, "full date", state.InputMetadata.DateInterpretation())
 			},
 		},
 		{
 			name: "Updates guess in state from new statement entry",
 			run: func(t *testing.T, state *statemod.State, guesser *MockIDateGuesser) {
 				// Expected guesser call
-				guesser.EXPECT().Guess("", sEntry).Return(aDate, true)
+				guesser.EXPECT().Interpret(dateguesser.Inputs{UserInput: "", StatementEntry: sEntry}).Return(dateguesser.Interpretation{Date: aDate, Description: "today"}, nil)
 
 				// Link
 				LinkDateGuesser(state, guesser)
@@ -250,11 +263,24 @@ func TestLinkDateGuesser(t *testing.T) {
 				assert.Equal(t, aDate, actualGuess)
 			},
 		},
+		{
+			name: "Updates guess in state from selected statement entry",
+			run: func(t *testing.T, state *statemod.State, guesser *MockIDateGuesser) {
+				otherDate := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+				otherEntry := finance.StatementEntry{Date: otherDate}
+				state.SetStatementEntries([]finance.StatementEntry{sEntry, otherEntry})
+				guesser.EXPECT().Interpret(dateguesser.Inputs{UserInput: "", StatementEntry: otherEntry}).Return(dateguesser.Interpretation{Date: otherDate, Description: "statement entry date"}, nil)
+				LinkDateGuesser(state, guesser)
+				state.SetCurrentStatementEntry(1)
+				actualGuess, _ := state.InputMetadata.GetDateGuess()
+				assert.Equal(t, otherDate, actualGuess)
+			},
+		},
 		{
 			name: "Clears guess when no guess",
 			run: func(t *testing.T, state *statemod.State, guesser *MockIDateGuesser) {
 				// Expected guesser call
-				guesser.EXPECT().Guess(aDateStr, sEntry).Return(time.Time{}, false)
+				guesser.EXPECT().Interpret(dateguesser.Inputs{UserInput: aDateStr, StatementEntry: sEntry}).Return(dateguesser.Interpretation{}, fmt.Errorf("invalid day"))
 
 				// Starts with a guess
 				state.InputMetadata.SetDateGuess(aDate)
@@ -268,6 +294,7 @@ func TestLinkDateGuesser(t *testing.T) {
 				// Ensure guess was cleared
 				_, found := state.InputMetadata.GetDateGuess()
 				assert.False(t, found)
+				assert.Equal(t, "invalid day", state.InputMetadata.DateInterpretation())
 			},
 		},
 	}
@@ -281,3 +308,91 @@ func TestLinkDateGuesser(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkStatementProgressStore(t *testing.T) {
+	entries := []finance.StatementEntry{{Description: "FOO"}, {Description: "BAR"}}
+	source := statemod.StatementSource{Name: "file", Key: "key"}
+	sourcedEntries := []finance.StatementEntry{
+		{Description: "FOO", Source: "file"},
+		{Description: "BAR", Source: "file"},
+	}
+
+	testcases := []struct {
+		name string
+		run  func(*testing.T, *statemod.State, *MockIStore)
+	}{
+		{
+			name: "Saves progress when statement changes",
+			run: func(t *testing.T, state *statemod.State, store *MockIStore) {
+				LinkStatementProgressStore(state, store)
+				store.EXPECT().Save("key", statemod.StatementProgress{
+					Remaining: sourcedEntries,
+					Discarded: []finance.StatementEntry{},
+				})
+				state.LoadStatement(source, entries)
+				store.EXPECT().Save("key", statemod.StatementProgress{
+					Remaining: sourcedEntries[1:],
+					Discarded: sourcedEntries[:1],
+				})
+				state.DiscardStatementEntry(0)
+			},
+		},
+		{
+			name: "Saves progress of each source",
+			run: func(t *testing.T, state *statemod.State, store *MockIStore) {
+				state.LoadStatement(source, entries)
+				LinkStatementProgressStore(state, store)
+				store.EXPECT().Save("key", statemod.StatementProgress{
+					Remaining: sourcedEntries,
+					Discarded: []finance.StatementEntry{},
+				})
+				store.EXPECT().Save("key2", statemod.StatementProgress{
+					Remaining: []finance.StatementEntry{{Description: "BAZ", Source: "file2"}},
+					Discarded: []finance.StatementEntry{},
+				})
+				state.LoadStatement(
+					statemod.StatementSource{Name: "file2", Key: "key2"},
+					[]finance.StatementEntry{{Description: "BAZ"}},
+				)
+			},
+		},
+		{
+			name: "Does not save if statement did not change",
+			run: func(t *testing.T, state *statemod.State, store *MockIStore) {
+				LinkStatementProgressStore(state, store)
+				store.EXPECT().Save("key", gomock.Any()).Times(1)
+				state.LoadStatement(source, entries)
+				state.InputMetadata.SetDateText("2023-01-01")
+			},
+		},
+		{
+			name: "Does not save statements without source",
+			run: func(t *testing.T, state *statemod.State, store *MockIStore) {
+				LinkStatementProgressStore(state, store)
+				state.SetStatementEntries(entries)
+			},
+		},
+		{
+			name: "Does not save while there is a resumable progress",
+			run: func(t *testing.T, state *statemod.State, store *MockIStore) {
+				LinkStatementProgressStore(state, store)
+				state.SetResumableStatementProgress("file", statemod.StatementProgress{Remaining: entries[1:]})
+				state.LoadStatement(source, entries)
+				store.EXPECT().Save("key", statemod.StatementProgress{
+					Remaining: sourcedEntries[1:],
+					Discarded: []finance.StatementEntry{},
+				})
+				state.ResumeStatementProgress()
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := NewMockIStore(ctrl)
+			testcase.run(t, statemod.InitialState(), store)
+		})
+	}
+}
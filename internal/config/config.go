@@ -15,8 +15,33 @@ type PrinterConfig struct {
 	NumLineBreaksAfter  int // Number of empty lines to print after a transaction.
 }
 
+// AccountGuesserConfig represents the values for configuring the account
+// guessers.
+type AccountGuesserConfig struct {
+	// File with the rules for the account guesser. Empty for the default
+	// file in the user config dir.
+	RulesFile string
+	// Position of the rules guesser in the chain of account guessers (0 to
+	// try it first).
+	RulesPriority int
+}
+
+// ImportCommand is the command that imports a statement without the TUI.
+const ImportCommand = "import"
+
+// ImportConfig represents the values for the import command.
+type ImportConfig struct {
+	// Minimum confidence (between 0 and 1) for a transaction to be imported.
+	Threshold float64
+	// File where the transactions below the threshold are written for
+	// review. Empty to leave them in a resumable queue for the TUI.
+	ReviewFile string
+}
+
 // Config is the root configuration for the entire app.
 type Config struct {
+	// Command to run. Empty for the TUI, or ImportCommand.
+	Command string
 	// File to where we will write Journal Entries.
 	DestFile string
 	// LedgerFile to pass to `hledger` executable. Empty string means none.
@@ -27,14 +52,26 @@ type Config struct {
 	LogFile string
 	// Level for logging
 	LogLevel string
+	// Base date for the date guesser: today, last (transaction) or statement
+	// (entry).
+	DateGuessBase string
+	// Commodity for ammounts entered without one. Empty to use the journal's
+	// default commodity, or the one most used with the account.
+	DefaultCommodity string
 	// Configures the transaction printer
 	PrinterConfig PrinterConfig
+	// Configures the account guessers
+	AccountGuesserConfig AccountGuesserConfig
 	// A initial file to load as a statement.
 	CSVStatementFile string
 	// A preset to use for the CSV statement.
 	CSVStatementPreset string
+	// The format of the statement file (csv, camt053 or qif). Empty to use the preset's.
+	CSVStatementFormat string
 	// Default file to load CSV sttatements from (interactively)
 	DefaultCSVStatementFile string
+	// Configures the import command
+	ImportConfig ImportConfig
 }
 
 func SetupFlags(flagSet *pflag.FlagSet) {
@@ -43,17 +80,28 @@ func SetupFlags(flagSet *pflag.FlagSet) {
 	flagSet.String("ledger-file", "", "Ledger File to pass to HLedger commands. If empty let ledger executable find it.")
 	flagSet.String("logfile", "", "File where to send log output. Empty for stderr.")
 	flagSet.String("loglevel", "WARN", "Level of logger. Defaults to warning.")
+	flagSet.String("date-guess-base", "statement", "Date guessed when none is entered, and from which partial dates take their month and year: today, last (the last transaction date) or statement (the statement entry date). Falls back to today.")
+	flagSet.String("default-commodity", "", "Commodity for ammounts entered without one. If empty, use the journal default commodity (D directive) or the commodity most used with the account.")
 
 	// Printer config
 	flagSet.Int("printer-line-break-before", 1, "Number of line breaks to print before a transaction.")
 	flagSet.Int("printer-line-break-after", 1, "Number of line breaks to print after a transaction.")
 
+	// Account guesser config
+	flagSet.String("account-rules-file", "", "File with rules to guess accounts. Defaults to ~/.config/addledger/account-rules.json.")
+	flagSet.Int("account-rules-priority", 1, "Position of the account rules among the account guessers (0 is the first, before the statement accounts).")
+
 	// Statement Loader config
 	flagSet.String("csv-statement-file", "", "CSV file to load as a statement.")
-	flagSet.String("csv-statement-preset", "", "Preset to use for CSV statement. If a simple filename is given, it will be searched in ~/.config/addledger/presets (with a .json extension).")
+	flagSet.String("csv-statement-preset", "", "Preset to use for CSV statement. If a simple filename is given, it will be searched in ~/.config/addledger/presets (with a .json extension). Use auto to infer the preset from the statement.")
+	flagSet.String("csv-statement-format", "", "Format of the statement file (csv, camt053 or qif). Overrides the format defined in the preset.")
 
 	// Statement Modal config
 	flagSet.String("default-csv-statement-file", "", "Default file to load statements from using the interactive modal.")
+
+	// Import command config
+	flagSet.Float64("import-threshold", 0.8, "Minimum confidence (between 0 and 1) for the import command to write a transaction.")
+	flagSet.String("import-review-file", "", "File where the import command writes the transactions below the threshold. Empty to leave them to be resumed in the UI.")
 }
 
 func Load(flagSet *pflag.FlagSet, args []string, loader ILoader) (*Config, error) {
@@ -84,18 +132,30 @@ func Load(flagSet *pflag.FlagSet, args []string, loader ILoader) (*Config, error
 
 	// Unpack
 	config := &Config{
+		Command:           flagSet.Arg(0),
 		DestFile:          viper.GetString("destfile"),
 		HLedgerExecutable: viper.GetString("hledger-executable"),
 		LedgerFile:        viper.GetString("ledger-file"),
 		LogFile:           viper.GetString("logfile"),
 		LogLevel:          viper.GetString("loglevel"),
+		DateGuessBase:     viper.GetString("date-guess-base"),
+		DefaultCommodity:  viper.GetString("default-commodity"),
 		PrinterConfig: PrinterConfig{
 			NumLineBreaksBefore: viper.GetInt("printer-line-break-before"),
 			NumLineBreaksAfter:  viper.GetInt("printer-line-break-after"),
 		},
+		AccountGuesserConfig: AccountGuesserConfig{
+			RulesFile:     viper.GetString("account-rules-file"),
+			RulesPriority: viper.GetInt("account-rules-priority"),
+		},
 		CSVStatementFile:        viper.GetString("csv-statement-file"),
 		CSVStatementPreset:      viper.GetString("csv-statement-preset"),
+		CSVStatementFormat:      viper.GetString("csv-statement-format"),
 		DefaultCSVStatementFile: viper.GetString("default-csv-statement-file"),
+		ImportConfig: ImportConfig{
+			Threshold:  viper.GetFloat64("import-threshold"),
+			ReviewFile: viper.GetString("import-review-file"),
+		},
 	}
 
 	// Load dynamic values
@@ -110,6 +170,15 @@ func Load(flagSet *pflag.FlagSet, args []string, loader ILoader) (*Config, error
 	if config.DestFile == "" {
 		return config, fmt.Errorf("missing destination file!")
 	}
+	switch config.Command {
+	case "":
+	case ImportCommand:
+		if config.CSVStatementFile == "" {
+			return config, fmt.Errorf("missing statement file to import (see --csv-statement-file)")
+		}
+	default:
+		return config, fmt.Errorf("unknown command: %s", config.Command)
+	}
 
 	return config, nil
 }
@@ -117,5 +186,5 @@ func Load(flagSet *pflag.FlagSet, args []string, loader ILoader) (*Config, error
 func LoadFromCommandLine() (*Config, error) {
 	loader := NewLoader()
 	SetupFlags(pflag.CommandLine)
-	return Load(pflag.CommandLine, os.Args, loader)
+	return Load(pflag.CommandLine, os.Args[1:], loader)
 }
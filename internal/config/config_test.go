@@ -95,11 +95,81 @@ func TestLoad(t *testing.T) {
 				flags := []string{
 					"--csv-statement-file=" + csvFile,
 					"--csv-statement-preset=" + fullCsvPresetFile,
+					"--csv-statement-format=camt053",
 				}
 				config, err := Load(c.flagSet, flags, c.loader)
 				assert.Nil(t, err)
 				assert.Equal(t, config.CSVStatementFile, csvFile)
 				assert.Equal(t, config.CSVStatementPreset, fullCsvPresetFile)
+				assert.Equal(t, config.CSVStatementFormat, "camt053")
+			},
+		},
+		{
+			name: "Defaults to no command",
+			run: func(t *testing.T, c *testcontext) {
+				config, err := Load(c.flagSet, []string{}, c.loader)
+				assert.Nil(t, err)
+				assert.Equal(t, "", config.Command)
+				assert.Equal(t, 0.8, config.ImportConfig.Threshold)
+				assert.Equal(t, "", config.ImportConfig.ReviewFile)
+				assert.Equal(t, AccountGuesserConfig{RulesPriority: 1}, config.AccountGuesserConfig)
+				assert.Equal(t, "", config.DefaultCommodity)
+				assert.Equal(t, "statement", config.DateGuessBase)
+			},
+		},
+		{
+			name: "With date guess base",
+			run: func(t *testing.T, c *testcontext) {
+				config, err := Load(c.flagSet, []string{"--date-guess-base=last"}, c.loader)
+				assert.Nil(t, err)
+				assert.Equal(t, "last", config.DateGuessBase)
+			},
+		},
+		{
+			name: "With default commodity",
+			run: func(t *testing.T, c *testcontext) {
+				config, err := Load(c.flagSet, []string{"--default-commodity=BRL"}, c.loader)
+				assert.Nil(t, err)
+				assert.Equal(t, "BRL", config.DefaultCommodity)
+			},
+		},
+		{
+			name: "With account guesser config",
+			run: func(t *testing.T, c *testcontext) {
+				flags := []string{"--account-rules-file=rules.json", "--account-rules-priority=0"}
+				config, err := Load(c.flagSet, flags, c.loader)
+				assert.Nil(t, err)
+				assert.Equal(t, AccountGuesserConfig{RulesFile: "rules.json", RulesPriority: 0}, config.AccountGuesserConfig)
+			},
+		},
+		{
+			name: "With import command",
+			run: func(t *testing.T, c *testcontext) {
+				flags := []string{
+					"import",
+					"--csv-statement-file=" + csvFile,
+					"--import-threshold=0.5",
+					"--import-review-file=review.journal",
+				}
+				config, err := Load(c.flagSet, flags, c.loader)
+				assert.Nil(t, err)
+				assert.Equal(t, ImportCommand, config.Command)
+				assert.Equal(t, 0.5, config.ImportConfig.Threshold)
+				assert.Equal(t, "review.journal", config.ImportConfig.ReviewFile)
+			},
+		},
+		{
+			name: "With import command without statement file",
+			run: func(t *testing.T, c *testcontext) {
+				_, err := Load(c.flagSet, []string{"import"}, c.loader)
+				assert.ErrorContains(t, err, "missing statement file to import")
+			},
+		},
+		{
+			name: "With unknown command",
+			run: func(t *testing.T, c *testcontext) {
+				_, err := Load(c.flagSet, []string{"foo"}, c.loader)
+				assert.ErrorContains(t, err, "unknown command: foo")
 			},
 		},
 		{
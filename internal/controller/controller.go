@@ -3,6 +3,8 @@ package controller
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/vitorqb/addledger/internal/dateguesser"
@@ -12,6 +14,7 @@ import (
 	"github.com/vitorqb/addledger/internal/listaction"
 	"github.com/vitorqb/addledger/internal/metaloader"
 	printermod "github.com/vitorqb/addledger/internal/printer"
+	"github.com/vitorqb/addledger/internal/services/statementloader"
 	statemod "github.com/vitorqb/addledger/internal/state"
 	"github.com/vitorqb/addledger/internal/userinput"
 	"github.com/vitorqb/addledger/internal/usermessenger"
@@ -19,9 +22,15 @@ import (
 
 //go:generate $MOCKGEN --source=controller.go --destination=../../mocks/controller/controller_mock.go
 
+// PresetPreviewRows is the number of rows displayed in the preset builder
+// preview.
+const PresetPreviewRows = 10
+
 // StatementLoader represents a component that loads a statement into the app state.
 type StatementLoader interface {
-	LoadFromFiles(statementFile, presetFile string) error
+	LoadFromFiles(statementFile, presetFile, format string) error
+	Preview(config statementloader.Config, n int) (statemod.PresetPreview, error)
+	SavePreset(name string, config statementloader.Config) (string, error)
 }
 
 // IInputController reacts to the user inputs and interactions.
@@ -52,6 +61,7 @@ type IInputController interface {
 	// Controls the Description input
 	OnDescriptionChanged(newText string)
 	OnDescriptionDone(source userinput.DoneSource)
+	OnDescriptionFillFromMatch()
 	OnDescriptionInsertFromContext()
 	OnDescriptionListAction(action listaction.ListAction)
 
@@ -62,10 +72,23 @@ type IInputController interface {
 	OnTagListAction(action listaction.ListAction)
 
 	// Controls statement
-	OnLoadStatement(csvFile string, presetFile string)
+	OnLoadStatement(csvFile string, presetFile string, format string)
 	OnPopStatement()
 	OnDiscardStatementEntry(i int)
+	OnDiscardDuplicatedStatementEntries()
+	OnUnloadStatementSource(i int)
+	OnSelectStatementEntry(i int)
+	OnEditStatementEntry(i int)
+	OnUpdateStatementEntry(i int, account, date, description, ammount string)
+	OnCancelStatementEntryEdit()
 	OnLoadStatementRequest()
+	OnResumeStatement(resume bool)
+
+	// Controls the preset builder
+	OnShowPresetBuilder()
+	OnHidePresetBuilder()
+	OnPresetBuilderChanged(config statementloader.Config)
+	OnSavePreset(name string, config statementloader.Config)
 
 	// Controls shortcuts modal
 	OnDisplayShortcutModal()
@@ -156,17 +179,53 @@ func (ic *InputController) OnPostingAccountDone(source userinput.DoneSource) {
 	}
 
 	// We have an account - save the posting
-	posting, found := ic.state.Transaction.Postings.Last()
+	posting, found := ic.postingWithoutAccount()
 	if !found {
 		posting = statemod.NewPostingData()
 		ic.state.Transaction.Postings.Append(posting)
 	}
 	posting.Account.Set(journal.Account(account))
 
+	// The ammounts of the postings from an even split are already known
+	if _, found := posting.Ammount.Get(); found {
+		ic.goToNextPosting()
+		return
+	}
+
 	// Go to ammount
 	ic.state.NextPhase()
 }
 
+// postingWithoutAccount returns the first posting without account. That's
+// the one being entered, or the first one created by an even split.
+func (ic *InputController) postingWithoutAccount() (*statemod.PostingData, bool) {
+	for _, posting := range ic.state.Transaction.Postings.Get() {
+		if _, found := posting.Account.Get(); !found {
+			return posting, true
+		}
+	}
+	return nil, false
+}
+
+// goToNextPosting goes to the account of the next posting without one (from
+// an even split), or of a new posting if there is balance outstanding, or
+// else to the confirmation.
+func (ic *InputController) goToNextPosting() {
+	if _, found := ic.postingWithoutAccount(); found {
+		ic.state.InputMetadata.SetPostingAccountText("")
+		ic.state.SetPhase(statemod.InputPostingAccount)
+		return
+	}
+	balance := userinput.PostingBalance(ic.state.Transaction.Postings.Get())
+	if !balance.IsZero() {
+		newPosting := statemod.NewPostingData()
+		ic.state.Transaction.Postings.Append(newPosting)
+		ic.state.SetPhase(statemod.InputPostingAccount)
+		return
+	}
+	ic.state.SetPhase(statemod.Confirmation)
+}
+
 // OnFinishPosting may be called by the user to signal it is done entering
 // postings. This is useful when the user is entering a transaction with
 // multiple commodities, since we can't know when the user is done entering
@@ -184,6 +243,13 @@ func (ic *InputController) OnFinishPosting() {
 		return
 	}
 
+	// If postings from an even split miss their accounts, nothing to do
+	if posting, found := ic.postingWithoutAccount(); found {
+		if _, found := posting.Ammount.Get(); found {
+			return
+		}
+	}
+
 	// We have multiple currencies or a single currency with zero balance, so
 	// finish the posting
 	ic.state.Transaction.Postings.Pop()
@@ -232,6 +298,8 @@ func (ic *InputController) OnPostingAmmountDone(source userinput.DoneSource) {
 	}
 
 	if success {
+		shares := ic.splitShares(ammount)
+
 		// Saves ammount
 		posting, found := ic.state.Transaction.Postings.Last()
 		if !found {
@@ -240,24 +308,41 @@ func (ic *InputController) OnPostingAmmountDone(source userinput.DoneSource) {
 		}
 		posting.Ammount.Set(ammount)
 
-		// If there is balance outstanding, go to next posting
-		balance := userinput.PostingBalance(ic.state.Transaction.Postings.Get())
-		if !balance.IsZero() {
-			newPosting := statemod.NewPostingData()
-			ic.state.Transaction.Postings.Append(newPosting)
-			ic.state.SetPhase(statemod.InputPostingAccount)
-			return
+		// Creates the postings for the other shares of an even split, with
+		// the accounts left for the user
+		for _, share := range shares {
+			sharePosting := statemod.NewPostingData()
+			sharePosting.Ammount.Set(share)
+			ic.state.Transaction.Postings.Append(sharePosting)
 		}
 
-		// Else, go to confirmation
-		ic.state.SetPhase(statemod.Confirmation)
+		ic.goToNextPosting()
 	}
 }
 
+// splitShares returns the ammounts of the other postings when the user
+// splits the pending balance evenly (e.g. `=3`) and the ammount is the first
+// share. Must be called before saving the ammount of the current posting.
+func (ic *InputController) splitShares(ammount finance.Ammount) []finance.Ammount {
+	n, err := userinput.TextToSplit(ic.state.InputMetadata.GetPostingAmmountText())
+	if err != nil {
+		return nil
+	}
+	balance := userinput.PostingBalance(ic.state.Transaction.Postings.Get())
+	if !balance.SingleCommodity() || balance.IsZero() {
+		return nil
+	}
+	shares := balance.Ammounts()[0].InvertSign().Split(n)
+	if !shares[0].Equal(ammount) {
+		return nil
+	}
+	return shares[1:]
+}
+
 func (ic *InputController) OnPostingAmmountChanged(text string) {
 	if text != ic.state.InputMetadata.GetPostingAmmountText() {
 		ic.state.InputMetadata.SetPostingAmmountText(text)
-		ammount, err := userinput.TextToAmmount(text)
+		ammount, err := userinput.TextToAmmountExpression(text)
 		if err != nil {
 			ic.state.InputMetadata.ClearPostingAmmountInput()
 		} else {
@@ -320,20 +405,92 @@ func (ic *InputController) OnDescriptionListAction(action listaction.ListAction)
 }
 
 func (ic *InputController) OnDescriptionDone(source userinput.DoneSource) {
+	ic.setDescription(source)
+	// Pre-fills the tags and comment from the statement entry.
+	if statementEntry, found := ic.state.CurrentStatementEntry(); found {
+		if len(ic.state.Transaction.Tags.Get()) == 0 {
+			ic.state.Transaction.Tags.Set(journal.StatementEntryTags(statementEntry))
+		}
+		if statementEntry.Comment != "" {
+			ic.state.Transaction.Comment.Set(statementEntry.Comment)
+		}
+	}
+	if len(ic.state.Transaction.Postings.Get()) == 0 {
+		newPosting := statemod.NewPostingData()
+		ic.state.Transaction.Postings.Append(newPosting)
+	}
+	ic.state.NextPhase()
+}
+
+// OnDescriptionFillFromMatch is like OnDescriptionDone, but fills the whole
+// transaction (postings, tags and comment) from the best matching transaction
+// and goes to the confirmation. The ammounts are scaled to the current
+// statement entry, if any.
+func (ic *InputController) OnDescriptionFillFromMatch() {
+	ic.setDescription(userinput.Context)
+	matches := ic.state.InputMetadata.MatchingTransactions()
+	if len(matches) == 0 {
+		ic.userMessenger.Info("No matching transaction to fill the transaction from")
+		ic.OnDescriptionDone(userinput.Context)
+		return
+	}
+	match := matches[0]
+	postings := match.Posting
+	tags := append([]journal.Tag{}, match.Tags...)
+	comment := match.Comment
+	if statementEntry, found := ic.state.CurrentStatementEntry(); found {
+		postings = journal.ScalePostings(postings, statementEntry.Account, statementEntry.Ammount.InvertSign())
+		// Tags and comment from the statement entry are specific to it, so
+		// they win over the ones from the match.
+		tags = mergeTags(tags, journal.StatementEntryTags(statementEntry))
+		if statementEntry.Comment != "" {
+			comment = statementEntry.Comment
+		}
+	}
+	ic.state.Transaction.Tags.Set(tags)
+	if comment != "" {
+		ic.state.Transaction.Comment.Set(comment)
+	}
+	postingsData := make([]*statemod.PostingData, len(postings))
+	for i, posting := range postings {
+		postingsData[i] = statemod.NewPostingData()
+		postingsData[i].Account.Set(journal.Account(posting.Account))
+		postingsData[i].Ammount.Set(posting.Ammount)
+	}
+	ic.state.Transaction.Postings.Set(postingsData)
+	ic.state.SetPhase(statemod.Confirmation)
+}
+
+// setDescription sets the transaction description from the user input or,
+// if the source is the context, from the selected description.
+func (ic *InputController) setDescription(source userinput.DoneSource) {
 	if source == userinput.Context {
 		// If we have a description from context, use it!
 		if descriptionFromContext := ic.state.InputMetadata.SelectedDescription(); descriptionFromContext != "" {
 			ic.OnDescriptionChanged(descriptionFromContext)
 		}
 	}
-
 	description := ic.state.InputMetadata.DescriptionText()
 	ic.state.Transaction.Description.Set(description)
-	if len(ic.state.Transaction.Postings.Get()) == 0 {
-		newPosting := statemod.NewPostingData()
-		ic.state.Transaction.Postings.Append(newPosting)
+}
+
+// mergeTags returns the tags with the overrides, replacing the tags with the
+// same name.
+func mergeTags(tags []journal.Tag, overrides []journal.Tag) []journal.Tag {
+	merged := []journal.Tag{}
+	for _, tag := range tags {
+		overridden := false
+		for _, override := range overrides {
+			if override.Name == tag.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, tag)
+		}
 	}
-	ic.state.NextPhase()
+	return append(merged, overrides...)
 }
 
 func (ic *InputController) OnDescriptionInsertFromContext() {
@@ -429,22 +586,138 @@ func (ic *InputController) OnDiscardStatementEntry(i int) {
 	ic.state.DiscardStatementEntry(i)
 }
 
+// OnDiscardDuplicatedStatementEntries implements IInputController.
+func (ic *InputController) OnDiscardDuplicatedStatementEntries() {
+	ic.state.DiscardDuplicatedStatementEntries()
+}
+
+// OnUnloadStatementSource implements IInputController. It unloads all
+// entries loaded from the same statement as the i-th statement entry.
+func (ic *InputController) OnUnloadStatementSource(i int) {
+	entries := ic.state.GetStatementEntries()
+	if i < 0 || i >= len(entries) {
+		return
+	}
+	ic.state.UnloadStatement(entries[i].Source)
+}
+
+// OnSelectStatementEntry implements IInputController. It makes the i-th
+// statement entry the current one and hides the statement modal.
+func (ic *InputController) OnSelectStatementEntry(i int) {
+	ic.state.SetCurrentStatementEntry(i)
+	ic.OnHideStatementModal()
+}
+
+// OnEditStatementEntry implements IInputController.
+func (ic *InputController) OnEditStatementEntry(i int) {
+	if i < 0 || i >= len(ic.state.GetStatementEntries()) {
+		return
+	}
+	ic.state.Display.StatementModal.SetEditingEntry(i)
+}
+
+// OnUpdateStatementEntry implements IInputController. The date and ammount
+// are parsed in the same way as the user input for a transaction. If the
+// ammount has no commodity, the entry commodity is kept.
+func (ic *InputController) OnUpdateStatementEntry(i int, account, date, description, ammount string) {
+	entries := ic.state.GetStatementEntries()
+	if i < 0 || i >= len(entries) {
+		return
+	}
+	entry := entries[i]
+	// Dates are edited in the format they are shown, so they don't depend on
+	// the base date of the date guesser.
+	date = strings.TrimSpace(date)
+	if date == "" {
+		ic.userMessenger.Error("Failed to update statement entry", fmt.Errorf("missing date"))
+		return
+	}
+	parsedDate, err := time.Parse(finance.StatementDateFormat, date)
+	if err != nil {
+		ic.userMessenger.Error("Failed to update statement entry", fmt.Errorf("invalid date (expected %s): %s", finance.StatementDateFormat, date))
+		return
+	}
+	parsedAmmount, err := userinput.TextToAmmount(ammount)
+	if err != nil {
+		ic.userMessenger.Error("Failed to update statement entry", fmt.Errorf("invalid ammount: %w", err))
+		return
+	}
+	if parsedAmmount.Commodity == "" {
+		parsedAmmount.Commodity = entry.Ammount.Commodity
+	}
+	entry.Account = account
+	entry.Date = parsedDate
+	entry.Description = description
+	entry.Ammount = parsedAmmount
+	ic.state.UpdateStatementEntry(i, entry)
+	ic.state.Display.StatementModal.ClearEditingEntry()
+}
+
+// OnCancelStatementEntryEdit implements IInputController.
+func (ic *InputController) OnCancelStatementEntryEdit() {
+	ic.state.Display.StatementModal.ClearEditingEntry()
+}
+
+// OnResumeStatement implements IInputController. If `resume` is true, the
+// saved progress for the loaded statement is resumed. Otherwise it's
+// discarded and the statement starts over.
+func (ic *InputController) OnResumeStatement(resume bool) {
+	if resume {
+		ic.state.ResumeStatementProgress()
+		return
+	}
+	ic.state.ClearResumableStatementProgress()
+}
+
 // OnLoadStatementRequest implements IInputController.
 func (ic *InputController) OnLoadStatementRequest() {
 	ic.state.Display.SetLoadStatementModal(true)
 }
 
+// OnShowPresetBuilder implements IInputController.
+func (ic *InputController) OnShowPresetBuilder() {
+	ic.state.Display.PresetBuilder.SetPreview(statemod.PresetPreview{})
+	ic.state.Display.PresetBuilder.SetVisible(true)
+}
+
+// OnHidePresetBuilder implements IInputController.
+func (ic *InputController) OnHidePresetBuilder() {
+	ic.state.Display.PresetBuilder.SetVisible(false)
+}
+
+// OnPresetBuilderChanged implements IInputController. It refreshes the
+// preview of the statement read with the preset being built.
+func (ic *InputController) OnPresetBuilderChanged(config statementloader.Config) {
+	preview, err := ic.csvStatementLoader.Preview(config, PresetPreviewRows)
+	if err != nil {
+		preview = statemod.PresetPreview{Err: err}
+	}
+	ic.state.Display.PresetBuilder.SetPreview(preview)
+}
+
+// OnSavePreset implements IInputController.
+func (ic *InputController) OnSavePreset(name string, config statementloader.Config) {
+	presetFile, err := ic.csvStatementLoader.SavePreset(name, config)
+	if err != nil {
+		ic.userMessenger.Error("Failed to save preset", err)
+		return
+	}
+	ic.userMessenger.Info("Saved preset " + presetFile)
+	ic.state.Display.PresetBuilder.SetVisible(false)
+}
+
 func (ic *InputController) OnShowStatementModal() {
 	ic.state.Display.StatementModal.SetVisible(true)
 }
 
 func (ic *InputController) OnHideStatementModal() {
+	ic.state.Display.StatementModal.ClearEditingEntry()
 	ic.state.Display.StatementModal.SetVisible(false)
 }
 
 // OnLoadStatement implements display.LoadStatementModalController.
-func (ic *InputController) OnLoadStatement(csvFile string, presetFile string) {
-	err := ic.csvStatementLoader.LoadFromFiles(csvFile, presetFile)
+func (ic *InputController) OnLoadStatement(csvFile string, presetFile string, format string) {
+	err := ic.csvStatementLoader.LoadFromFiles(csvFile, presetFile, format)
 	if err != nil {
 		ic.userMessenger.Error("Failed to load statement", err)
 		return
@@ -460,13 +733,25 @@ func (ic *InputController) OnUndo() {
 		ic.state.Transaction.Date.Clear()
 		ic.state.PrevPhase()
 	case statemod.InputTags:
-		// Clear description, tags and go back
+		// Clear description, tags, comment and go back
 		ic.state.Transaction.Description.Clear()
 		ic.state.InputMetadata.SetDescriptionText("")
 		ic.state.Transaction.Tags.Clear()
+		ic.state.Transaction.Comment.Clear()
 		ic.state.PrevPhase()
 	case statemod.InputPostingAccount:
-		ic.state.Transaction.Postings.Pop()
+		// Removes the posting being entered, and the ones from an even split
+		// that miss their accounts
+		for {
+			posting, found := ic.state.Transaction.Postings.Last()
+			if !found {
+				break
+			}
+			if _, found := posting.Account.Get(); found {
+				break
+			}
+			ic.state.Transaction.Postings.Pop()
+		}
 		if posting, found := ic.state.Transaction.Postings.Last(); found {
 			// We have a posting to go back to - clear last ammount and go back
 			posting.Ammount.Clear()
@@ -15,6 +15,7 @@ import (
 	"github.com/vitorqb/addledger/internal/journal"
 	"github.com/vitorqb/addledger/internal/listaction"
 	printermod "github.com/vitorqb/addledger/internal/printer"
+	"github.com/vitorqb/addledger/internal/services/statementloader"
 	statemod "github.com/vitorqb/addledger/internal/state"
 	"github.com/vitorqb/addledger/internal/testutils"
 	"github.com/vitorqb/addledger/internal/userinput"
@@ -174,6 +175,102 @@ func TestInputController(t *testing.T) {
 				assert.Equal(t, "FOO", foundDescription)
 			},
 		},
+		{
+			name: "Description done pre-fills tags and comment from statement",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetStatementEntries([]finance.StatementEntry{{
+					Fields:    map[string]string{"bankref": "REF1", "memo": "foo"},
+					TagFields: []string{"bankref", "category"},
+					Comment:   "memo: foo",
+				}})
+				c.state.SetPhase(statemod.InputDescription)
+				c.controller.OnDescriptionChanged("FOO")
+				c.controller.OnDescriptionDone(userinput.Input)
+				assert.Equal(t, []journal.Tag{{Name: "bankref", Value: "REF1"}}, c.state.Transaction.Tags.Get())
+				comment, _ := c.state.Transaction.Comment.Get()
+				assert.Equal(t, "memo: foo", comment)
+
+				// Undo clears them
+				c.controller.OnUndo()
+				assert.Empty(t, c.state.Transaction.Tags.Get())
+				_, found := c.state.Transaction.Comment.Get()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Description fill from match copies the best match",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				match := *testutils.Transaction_1(t)
+				match.Tags = []journal.Tag{{Name: "trip", Value: "paris"}}
+				match.Comment = "a comment"
+				c.state.InputMetadata.SetMatchingTransactions([]journal.Transaction{match, *testutils.Transaction_2(t)})
+				c.state.SetPhase(statemod.InputDescription)
+				c.controller.OnDescriptionChanged("FOO")
+				c.controller.OnDescriptionFillFromMatch()
+
+				assert.Equal(t, statemod.Confirmation, c.state.CurrentPhase())
+				description, _ := c.state.Transaction.Description.Get()
+				assert.Equal(t, "FOO", description)
+				assert.Equal(t, match.Tags, c.state.Transaction.Tags.Get())
+				comment, _ := c.state.Transaction.Comment.Get()
+				assert.Equal(t, "a comment", comment)
+				postings := c.state.Transaction.Postings.Get()
+				assert.Len(t, postings, 2)
+				for i, posting := range postings {
+					account, _ := posting.Account.Get()
+					assert.Equal(t, journal.Account(match.Posting[i].Account), account)
+					ammount, _ := posting.Ammount.Get()
+					assert.Equal(t, match.Posting[i].Ammount, ammount)
+				}
+
+				// Rejecting lets the user add postings
+				c.controller.OnInputRejection()
+				assert.Equal(t, statemod.InputPostingAccount, c.state.CurrentPhase())
+				assert.Len(t, c.state.Transaction.Postings.Get(), 3)
+			},
+		},
+		{
+			name: "Description fill from match scales to the statement entry",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				match := *testutils.Transaction_1(t)
+				match.Tags = []journal.Tag{{Name: "bankref", Value: "OLD"}, {Name: "trip", Value: "paris"}}
+				c.state.InputMetadata.SetMatchingTransactions([]journal.Transaction{match})
+				c.state.SetStatementEntries([]finance.StatementEntry{{
+					Account:   "ACC2",
+					Ammount:   finance.Ammount{Commodity: "EUR", Quantity: decimal.New(305, -1)},
+					Fields:    map[string]string{"bankref": "REF1"},
+					TagFields: []string{"bankref"},
+				}})
+				c.state.SetPhase(statemod.InputDescription)
+				c.controller.OnDescriptionChanged("FOO")
+				c.controller.OnDescriptionFillFromMatch()
+
+				assert.Equal(t, statemod.Confirmation, c.state.CurrentPhase())
+				expectedTags := []journal.Tag{{Name: "trip", Value: "paris"}, {Name: "bankref", Value: "REF1"}}
+				assert.Equal(t, expectedTags, c.state.Transaction.Tags.Get())
+				postings := c.state.Transaction.Postings.Get()
+				assert.Len(t, postings, 2)
+				firstAmmount, _ := postings[0].Ammount.Get()
+				assert.True(t, firstAmmount.Equal(finance.Ammount{Commodity: "EUR", Quantity: decimal.New(305, -1)}))
+				secondAmmount, _ := postings[1].Ammount.Get()
+				assert.True(t, secondAmmount.Equal(finance.Ammount{Commodity: "EUR", Quantity: decimal.New(-305, -1)}))
+			},
+		},
+		{
+			name: "Description fill from match without a match goes on as done",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetPhase(statemod.InputDescription)
+				c.controller.OnDescriptionChanged("FOO")
+				c.controller.OnDescriptionFillFromMatch()
+				assert.Equal(t, statemod.InputTags, c.state.CurrentPhase())
+				description, _ := c.state.Transaction.Description.Get()
+				assert.Equal(t, "FOO", description)
+			},
+		},
 		{
 			name: "OnPostingAccountDone from context",
 			opts: defaultOpts,
@@ -276,6 +373,18 @@ func TestInputController(t *testing.T) {
 				assert.Equal(t, 0, len(c.state.GetStatementEntries()))
 			},
 		},
+		{
+			name: "OnInputConfirmation pops the current statement entry",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetStatementEntries([]finance.StatementEntry{{Description: "FOO"}, {Description: "BAR"}})
+				c.state.SetCurrentStatementEntry(1)
+				c.state.Transaction = testutils.TransactionData_1(t)
+				c.metaLoader.EXPECT().LoadAccounts().Times(1)
+				c.controller.OnInputConfirmation()
+				assert.Equal(t, []finance.StatementEntry{{Description: "FOO"}}, c.state.GetStatementEntries())
+			},
+		},
 		{
 			name: "OnInputConfirmation fixes date guess",
 			opts: defaultOpts,
@@ -352,6 +461,16 @@ func TestInputController(t *testing.T) {
 				assert.Equal(t, anotherAmmount, ammount)
 			},
 		},
+		{
+			name: "OnPostingAmmountChanged evaluates expressions",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.OnPostingAmmountChanged("EUR 10.20+2")
+				ammount, found := c.state.InputMetadata.GetPostingAmmountInput()
+				assert.True(t, found)
+				assert.True(t, anotherAmmount.Equal(ammount))
+			},
+		},
 		{
 			name: "OnPostingAmmountChanged saves to state parse fails",
 			opts: defaultOpts,
@@ -454,6 +573,80 @@ func TestInputController(t *testing.T) {
 				assert.Equal(t, anotherAmmountNeg, secondPostingAmmount)
 			},
 		},
+		{
+			name: "Creates the postings of an even split of the pending balance",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				eur := func(x string) finance.Ammount {
+					return finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+				}
+				enterAccount := func(account string) {
+					c.controller.OnPostingAccountChanged(account)
+					c.controller.OnPostingAccountDone(userinput.Input)
+				}
+				c.state.SetPhase(statemod.InputPostingAccount)
+				enterAccount("FOO")
+				c.controller.OnPostingAmmountChanged("EUR 100")
+				c.controller.OnPostingAmmountDone(userinput.Input)
+				enterAccount("BAR")
+				c.controller.OnPostingAmmountChanged("=3")
+				c.state.InputMetadata.SetPostingAmmountGuess(eur("-33.34"))
+				c.controller.OnPostingAmmountDone(userinput.Context)
+
+				// The other shares are created without accounts
+				postings := c.state.Transaction.Postings.Get()
+				assert.Len(t, postings, 4)
+				for i, expected := range []finance.Ammount{eur("100"), eur("-33.34"), eur("-33.33"), eur("-33.33")} {
+					ammount, _ := postings[i].Ammount.Get()
+					assert.Equal(t, expected, ammount)
+				}
+				_, found := postings[2].Account.Get()
+				assert.False(t, found)
+				assert.Equal(t, statemod.InputPostingAccount, c.state.CurrentPhase())
+
+				// The user only enters their accounts
+				enterAccount("BAZ")
+				assert.Equal(t, statemod.InputPostingAccount, c.state.CurrentPhase())
+				assert.Equal(t, "", c.state.InputMetadata.PostingAccountText())
+				enterAccount("QUX")
+				assert.Equal(t, statemod.Confirmation, c.state.CurrentPhase())
+				completePostings, err := userinput.PostingsFromData(c.state.Transaction.Postings.Get())
+				assert.Nil(t, err)
+				assert.Equal(t, []journal.Posting{
+					{Account: "FOO", Ammount: eur("100")},
+					{Account: "BAR", Ammount: eur("-33.34")},
+					{Account: "BAZ", Ammount: eur("-33.33")},
+					{Account: "QUX", Ammount: eur("-33.33")},
+				}, completePostings)
+			},
+		},
+		{
+			name: "Undo removes the postings of an even split",
+			opts: defaultOpts,
+			run: func(t *testing.T, c *testcontext) {
+				eur := func(x string) finance.Ammount {
+					return finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+				}
+				c.state.SetPhase(statemod.InputPostingAccount)
+				c.controller.OnPostingAccountChanged("FOO")
+				c.controller.OnPostingAccountDone(userinput.Input)
+				c.controller.OnPostingAmmountChanged("EUR 90")
+				c.controller.OnPostingAmmountDone(userinput.Input)
+				c.controller.OnPostingAccountChanged("BAR")
+				c.controller.OnPostingAccountDone(userinput.Input)
+				c.controller.OnPostingAmmountChanged("=3")
+				c.state.InputMetadata.SetPostingAmmountGuess(eur("-30"))
+				c.controller.OnPostingAmmountDone(userinput.Context)
+				assert.Len(t, c.state.Transaction.Postings.Get(), 4)
+				c.controller.OnFinishPosting()
+				assert.Equal(t, statemod.InputPostingAccount, c.state.CurrentPhase())
+				c.controller.OnUndo()
+				assert.Len(t, c.state.Transaction.Postings.Get(), 2)
+				assert.Equal(t, statemod.InputPostingAmmount, c.state.CurrentPhase())
+				_, found := c.state.Transaction.Postings.Get()[1].Ammount.Get()
+				assert.False(t, found)
+			},
+		},
 		{
 			name: "Must write to ",
 			opts: defaultOpts,
@@ -731,6 +924,7 @@ func TestInputController__OnUndo(t *testing.T) {
 		eventBus           *MockIEventBus
 		csvStatementLoader *MockStatementLoader
 		userMessenger      *MockIUserMessenger
+		dateGuesser        *MockIDateGuesser
 	}
 
 	type testcase struct {
@@ -915,6 +1109,158 @@ func TestInputController__OnUndo(t *testing.T) {
 				assert.Equal(t, stmEntries[0], c.state.StatementEntries[0])
 			},
 		},
+		{
+			name: "OnDiscardDuplicatedStatementEntries",
+			run: func(t *testing.T, c *testcontext) {
+				duplicated := testutils.StatementEntry_1(t)
+				duplicated.Duplicate = true
+				stmEntries := []finance.StatementEntry{duplicated, testutils.StatementEntry_2(t)}
+				c.state.SetStatementEntries(stmEntries)
+				c.controller.OnDiscardDuplicatedStatementEntries()
+				assert.Equal(t, []finance.StatementEntry{testutils.StatementEntry_2(t)}, c.state.StatementEntries)
+			},
+		},
+		{
+			name: "OnUnloadStatementSource",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.LoadStatement(statemod.StatementSource{Name: "file1"}, []finance.StatementEntry{testutils.StatementEntry_1(t)})
+				c.state.LoadStatement(statemod.StatementSource{Name: "file2"}, []finance.StatementEntry{testutils.StatementEntry_2(t)})
+				c.controller.OnUnloadStatementSource(5)
+				assert.Len(t, c.state.StatementEntries, 2)
+				c.controller.OnUnloadStatementSource(0)
+				assert.Len(t, c.state.StatementEntries, 1)
+				assert.Equal(t, "file2", c.state.StatementEntries[0].Source)
+			},
+		},
+		{
+			name: "OnSelectStatementEntry",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					testutils.StatementEntry_1(t),
+					testutils.StatementEntry_2(t),
+				})
+				c.state.Display.StatementModal.SetVisible(true)
+				c.controller.OnSelectStatementEntry(1)
+				current, _ := c.state.CurrentStatementEntry()
+				assert.Equal(t, testutils.StatementEntry_2(t), current)
+				assert.False(t, c.state.Display.StatementModal.Visible())
+			},
+		},
+		{
+			name: "OnEditStatementEntry",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetStatementEntries([]finance.StatementEntry{testutils.StatementEntry_1(t)})
+				c.controller.OnEditStatementEntry(1)
+				_, editing := c.state.Display.StatementModal.EditingEntry()
+				assert.False(t, editing)
+				c.controller.OnEditStatementEntry(0)
+				index, editing := c.state.Display.StatementModal.EditingEntry()
+				assert.True(t, editing)
+				assert.Equal(t, 0, index)
+				c.controller.OnCancelStatementEntryEdit()
+				_, editing = c.state.Display.StatementModal.EditingEntry()
+				assert.False(t, editing)
+			},
+		},
+		{
+			name: "OnUpdateStatementEntry",
+			run: func(t *testing.T, c *testcontext) {
+				entry := testutils.StatementEntry_1(t)
+				c.state.SetStatementEntries([]finance.StatementEntry{entry})
+				c.state.Display.StatementModal.SetEditingEntry(0)
+				date := time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC)
+				c.controller.OnUpdateStatementEntry(0, "ACC", "2023-10-31", "Supermarket", "-12.21")
+				updated := c.state.StatementEntries[0]
+				assert.Equal(t, "ACC", updated.Account)
+				assert.Equal(t, date, updated.Date)
+				assert.Equal(t, "Supermarket", updated.Description)
+				assert.Equal(t, entry.Ammount.Commodity, updated.Ammount.Commodity)
+				assert.Equal(t, "-12.21", updated.Ammount.Quantity.String())
+				_, editing := c.state.Display.StatementModal.EditingEntry()
+				assert.False(t, editing)
+			},
+		},
+		{
+			name: "OnUpdateStatementEntry with commodity",
+			run: func(t *testing.T, c *testcontext) {
+				entry := testutils.StatementEntry_1(t)
+				c.state.SetStatementEntries([]finance.StatementEntry{entry})
+				c.controller.OnUpdateStatementEntry(0, "ACC", entry.Date.Format(finance.StatementDateFormat), "Supermarket", "BRL 1")
+				assert.Equal(t, "BRL", c.state.StatementEntries[0].Ammount.Commodity)
+				assert.Equal(t, entry.Date, c.state.StatementEntries[0].Date)
+			},
+		},
+		{
+			name: "OnUpdateStatementEntry invalid date",
+			run: func(t *testing.T, c *testcontext) {
+				entry := testutils.StatementEntry_1(t)
+				c.state.SetStatementEntries([]finance.StatementEntry{entry})
+				c.state.Display.StatementModal.SetEditingEntry(0)
+				c.userMessenger.EXPECT().Error("Failed to update statement entry", gomock.Any())
+				c.controller.OnUpdateStatementEntry(0, "ACC", "foo", "Supermarket", "1")
+				assert.Equal(t, entry, c.state.StatementEntries[0])
+				_, editing := c.state.Display.StatementModal.EditingEntry()
+				assert.True(t, editing)
+			},
+		},
+		{
+			name: "OnUpdateStatementEntry empty date",
+			run: func(t *testing.T, c *testcontext) {
+				entry := testutils.StatementEntry_1(t)
+				c.state.SetStatementEntries([]finance.StatementEntry{entry})
+				c.state.Display.StatementModal.SetEditingEntry(0)
+				c.userMessenger.EXPECT().Error("Failed to update statement entry", fmt.Errorf("missing date"))
+				c.controller.OnUpdateStatementEntry(0, "ACC", " ", "Supermarket", "1")
+				assert.Equal(t, entry, c.state.StatementEntries[0])
+				_, editing := c.state.Display.StatementModal.EditingEntry()
+				assert.True(t, editing)
+			},
+		},
+		{
+			name: "OnUpdateStatementEntry invalid ammount",
+			run: func(t *testing.T, c *testcontext) {
+				entry := testutils.StatementEntry_1(t)
+				c.state.SetStatementEntries([]finance.StatementEntry{entry})
+				c.userMessenger.EXPECT().Error("Failed to update statement entry", gomock.Any())
+				c.controller.OnUpdateStatementEntry(0, "ACC", "2023-10-31", "Supermarket", "abc")
+				assert.Equal(t, entry, c.state.StatementEntries[0])
+			},
+		},
+		{
+			name: "OnResumeStatement resumes",
+			run: func(t *testing.T, c *testcontext) {
+				progress := statemod.StatementProgress{
+					Remaining: []finance.StatementEntry{testutils.StatementEntry_2(t)},
+					Discarded: []finance.StatementEntry{testutils.StatementEntry_1(t)},
+				}
+				c.state.SetResumableStatementProgress("file", progress)
+				c.state.LoadStatement(statemod.StatementSource{Name: "file", Key: "key"}, []finance.StatementEntry{
+					testutils.StatementEntry_1(t),
+					testutils.StatementEntry_2(t),
+				})
+				c.controller.OnResumeStatement(true)
+				resumed := c.state.StatementProgress("file")
+				assert.Len(t, resumed.Remaining, 1)
+				assert.Equal(t, progress.Remaining[0].Description, resumed.Remaining[0].Description)
+				assert.Len(t, resumed.Discarded, 1)
+				assert.Equal(t, progress.Discarded[0].Description, resumed.Discarded[0].Description)
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "OnResumeStatement starts over",
+			run: func(t *testing.T, c *testcontext) {
+				entries := []finance.StatementEntry{testutils.StatementEntry_1(t)}
+				c.state.SetResumableStatementProgress("file", statemod.StatementProgress{})
+				c.state.LoadStatement(statemod.StatementSource{Name: "file", Key: "key"}, entries)
+				c.controller.OnResumeStatement(false)
+				assert.Len(t, c.state.StatementEntries, 1)
+				assert.Equal(t, entries[0].Description, c.state.StatementEntries[0].Description)
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
 		{
 			name: "OnLoadStatementRequest",
 			run: func(t *testing.T, c *testcontext) {
@@ -930,8 +1276,8 @@ func TestInputController__OnUndo(t *testing.T) {
 				c.state.Display.SetLoadStatementModal(true)
 				csvPath := testutils.TestDataPath(t, "statement.csv")
 				presetPath := testutils.TestDataPath(t, "preset.json")
-				c.csvStatementLoader.EXPECT().LoadFromFiles(csvPath, presetPath).Return(nil)
-				c.controller.OnLoadStatement(csvPath, presetPath)
+				c.csvStatementLoader.EXPECT().LoadFromFiles(csvPath, presetPath, "camt053").Return(nil)
+				c.controller.OnLoadStatement(csvPath, presetPath, "camt053")
 				// Ensure modal is closed
 				assert.False(t, c.state.Display.LoadStatementModal())
 			},
@@ -949,12 +1295,62 @@ func TestInputController__OnUndo(t *testing.T) {
 		{
 			name: "OnLoadStatement loads statement warns user on error",
 			run: func(t *testing.T, c *testcontext) {
-				c.csvStatementLoader.EXPECT().LoadFromFiles("foo", "bar").Return(fmt.Errorf("no such file or directory"))
+				c.csvStatementLoader.EXPECT().LoadFromFiles("foo", "bar", "").Return(fmt.Errorf("no such file or directory"))
 				c.userMessenger.EXPECT().Error(gomock.Any(), gomock.Any()).Do(func(msg string, err error) {
 					assert.Contains(t, msg, "Failed to load statement")
 					assert.Contains(t, err.Error(), "no such file or directory")
 				})
-				c.controller.OnLoadStatement("foo", "bar")
+				c.controller.OnLoadStatement("foo", "bar", "")
+			},
+		},
+		{
+			name: "OnShowPresetBuilder and OnHidePresetBuilder",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.Display.PresetBuilder.SetPreview(statemod.PresetPreview{Err: assert.AnError})
+				c.controller.OnShowPresetBuilder()
+				assert.True(t, c.state.Display.PresetBuilder.Visible())
+				assert.Equal(t, statemod.PresetPreview{}, c.state.Display.PresetBuilder.Preview())
+				c.controller.OnHidePresetBuilder()
+				assert.False(t, c.state.Display.PresetBuilder.Visible())
+			},
+		},
+		{
+			name: "OnPresetBuilderChanged sets the preview",
+			run: func(t *testing.T, c *testcontext) {
+				config := statementloader.Config{File: "foo.csv", DateFieldIndex: 1}
+				preview := statemod.PresetPreview{Rows: [][]string{{"a"}}, Entries: []finance.StatementEntry{{Description: "a"}}}
+				c.csvStatementLoader.EXPECT().Preview(config, PresetPreviewRows).Return(preview, nil)
+				c.controller.OnPresetBuilderChanged(config)
+				assert.Equal(t, preview, c.state.Display.PresetBuilder.Preview())
+			},
+		},
+		{
+			name: "OnPresetBuilderChanged sets the error in the preview",
+			run: func(t *testing.T, c *testcontext) {
+				c.csvStatementLoader.EXPECT().Preview(gomock.Any(), gomock.Any()).Return(statemod.PresetPreview{}, assert.AnError)
+				c.controller.OnPresetBuilderChanged(statementloader.Config{})
+				assert.Equal(t, statemod.PresetPreview{Err: assert.AnError}, c.state.Display.PresetBuilder.Preview())
+			},
+		},
+		{
+			name: "OnSavePreset saves the preset and hides the builder",
+			run: func(t *testing.T, c *testcontext) {
+				config := statementloader.Config{Separator: ";"}
+				c.state.Display.PresetBuilder.SetVisible(true)
+				c.csvStatementLoader.EXPECT().SavePreset("mybank", config).Return("/presets/mybank.json", nil)
+				c.userMessenger.EXPECT().Info("Saved preset /presets/mybank.json")
+				c.controller.OnSavePreset("mybank", config)
+				assert.False(t, c.state.Display.PresetBuilder.Visible())
+			},
+		},
+		{
+			name: "OnSavePreset warns user on error",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.Display.PresetBuilder.SetVisible(true)
+				c.csvStatementLoader.EXPECT().SavePreset("", gomock.Any()).Return("", assert.AnError)
+				c.userMessenger.EXPECT().Error("Failed to save preset", assert.AnError)
+				c.controller.OnSavePreset("", statementloader.Config{})
+				assert.True(t, c.state.Display.PresetBuilder.Visible())
 			},
 		},
 	}
@@ -970,11 +1366,11 @@ func TestInputController__OnUndo(t *testing.T) {
 			c.eventBus = NewMockIEventBus(ctrl)
 			c.csvStatementLoader = NewMockStatementLoader(ctrl)
 			c.userMessenger = NewMockIUserMessenger(ctrl)
-			dateGuesser := NewMockIDateGuesser(ctrl)
+			c.dateGuesser = NewMockIDateGuesser(ctrl)
 			c.controller, err = NewController(c.state,
 				WithOutput(&bytesBuffer),
 				WithEventBus(c.eventBus),
-				WithDateGuesser(dateGuesser),
+				WithDateGuesser(c.dateGuesser),
 				WithMetaLoader(NewMockIMetaLoader(ctrl)),
 				WithPrinter(printermod.New(2, 2)),
 				WithCSVStatementLoader(c.csvStatementLoader),
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/vitorqb/addledger/internal/finance"
@@ -14,6 +15,23 @@ import (
 // Global regex to match `-1`, `-2`, ...
 var SubtractDayRegex = regexp.MustCompile(`^\-[0-9]+$`)
 
+// Global regex to match `+1`, `+2`, ...
+var AddDayRegex = regexp.MustCompile(`^\+[0-9]+$`)
+
+// Global regex to match `2 days ago`, `1 week ago`, `3 months ago`, ...
+var AgoRegex = regexp.MustCompile(`^([0-9]+) (day|week|month)s? ago$`)
+
+// Global regex to match `last monday`, `last fri`, ...
+var LastWeekdayRegex = regexp.MustCompile(`^last ([a-z]+)$`)
+
+// Global regexes to match ISO dates and partial dates.
+var (
+	FullDateRegex  = regexp.MustCompile(`^([0-9]{4})-([0-9]{1,2})-([0-9]{1,2})$`)
+	MonthDayRegex  = regexp.MustCompile(`^([0-9]{1,2})-([0-9]{1,2})$`)
+	DayRegex       = regexp.MustCompile(`^([0-9]{1,2})$`)
+	SlashDateRegex = regexp.MustCompile(`^([0-9]{1,2})/([0-9]{1,2})(/([0-9]{4}))?$`)
+)
+
 // IClock is a helper interface for useful time functions.
 type IClock interface {
 	Now() time.Time
@@ -27,62 +45,230 @@ var _ IClock = &Clock{}
 // Now implements IClock.
 func (*Clock) Now() time.Time { return time.Now() }
 
+// BaseStrategy is the strategy for the base date, which is guessed when the
+// user has entered nothing, and from which partial dates (e.g. `15`) take
+// their month and year.
+type BaseStrategy string
+
+const (
+	// TodayBase uses today.
+	TodayBase BaseStrategy = "today"
+	// LastTransactionBase uses the date of the last transaction entered.
+	LastTransactionBase BaseStrategy = "last"
+	// StatementBase uses the date of the current statement entry.
+	StatementBase BaseStrategy = "statement"
+)
+
+// ParseBaseStrategy parses a BaseStrategy.
+func ParseBaseStrategy(x string) (BaseStrategy, error) {
+	switch strategy := BaseStrategy(x); strategy {
+	case TodayBase, LastTransactionBase, StatementBase:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("invalid date guess base: %s (expected today, last or statement)", x)
+}
+
+// Inputs are the inputs that are used for guessing.
+type Inputs struct {
+	// UserInput is the text the user has inputted in the date field.
+	UserInput string
+	// StatementEntry is the current statement entry, if any.
+	StatementEntry finance.StatementEntry
+	// LastTransactionDate is the date of the last transaction entered, if any.
+	LastTransactionDate time.Time
+}
+
+// Interpretation is a date guessed from the user input, and how the input was
+// interpreted (e.g. `last Friday`).
+type Interpretation struct {
+	Date        time.Time
+	Description string
+}
+
 // IDateGuesser proves an interface for an engine that guesses the date the user
 // wants from it's text input and a statement entry.
 type IDateGuesser interface {
 	// Guess returns a tuple of (guess, success).
-	Guess(userInput string, statementEntry finance.StatementEntry) (guess time.Time, success bool)
+	Guess(inputs Inputs) (guess time.Time, success bool)
+	// Interpret returns the guess and how the user input was interpreted, or
+	// an error explaining why the input is not a valid date.
+	Interpret(inputs Inputs) (Interpretation, error)
 }
 
 // DateGuesser implemnets IDateGuesser.
 type DateGuesser struct {
 	Clock IClock
+	// DayFirst is true if dates with slashes are day/month (e.g. `27/09`),
+	// and false if they are month/day (e.g. `09/27`).
+	DayFirst bool
+	// Base is the strategy for the base date. Empty for StatementBase.
+	Base BaseStrategy
 }
 
 var _ IDateGuesser = &DateGuesser{}
 
-// New returns a new instance of a DateGuesser
-func New() (*DateGuesser, error) { return &DateGuesser{&Clock{}}, nil }
+// New returns a new instance of a DateGuesser, with the order of day and
+// month from the user's locale.
+func New(base BaseStrategy) (*DateGuesser, error) {
+	return &DateGuesser{Clock: &Clock{}, DayFirst: DayFirstLocale(Locale()), Base: base}, nil
+}
 
 // Guess implements IDateGuesser
-func (dg *DateGuesser) Guess(userInput string, statementEntry finance.StatementEntry) (guess time.Time, success bool) {
+func (dg *DateGuesser) Guess(inputs Inputs) (guess time.Time, success bool) {
+	interpretation, err := dg.Interpret(inputs)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return interpretation.Date, true
+}
+
+// base returns the base date for the inputs and its name (e.g. `the
+// statement entry`), falling back to today when the base date is not known.
+func (dg *DateGuesser) base(now time.Time, inputs Inputs) (time.Time, string) {
+	switch dg.Base {
+	case TodayBase:
+	case LastTransactionBase:
+		if !inputs.LastTransactionDate.IsZero() {
+			return inputs.LastTransactionDate, "the last transaction"
+		}
+	default:
+		if !inputs.StatementEntry.Date.IsZero() {
+			return inputs.StatementEntry.Date, "the statement entry"
+		}
+	}
+	return now, "today"
+}
+
+// Interpret implements IDateGuesser
+func (dg *DateGuesser) Interpret(inputs Inputs) (Interpretation, error) {
 	now := dg.Clock.Now()
+	base, baseName := dg.base(now, inputs)
+	userInput := strings.ToLower(strings.TrimSpace(inputs.UserInput))
 
-	// Empty user input and no statementEntry - use today
-	if userInput == "" && statementEntry.Date.IsZero() {
-		return now, true
+	// Empty user input - use the base date
+	if userInput == "" {
+		if baseName == "today" {
+			return Interpretation{base, "today"}, nil
+		}
+		return Interpretation{base, "date of " + baseName}, nil
 	}
 
-	// Empty user input and statementEntry - use statementEntry
-	if userInput == "" && !statementEntry.Date.IsZero() {
-		return statementEntry.Date, true
+	switch userInput {
+	case "today":
+		return Interpretation{now, "today"}, nil
+	case "yesterday":
+		return Interpretation{now.AddDate(0, 0, -1), "yesterday"}, nil
+	case "tomorrow":
+		return Interpretation{now.AddDate(0, 0, 1), "tomorrow"}, nil
 	}
 
 	// User entered full date - we are happy
-	if date, err := time.Parse("2006-01-02", userInput); err == nil {
-		return date, true
+	if match := FullDateRegex.FindStringSubmatch(userInput); match != nil {
+		return dateFromStrings(match[1], match[2], match[3], "full date")
 	}
 
-	// User entered partial day (without month/year)
-	year := fmt.Sprint(now.Year())
-	month := fmt.Sprint(int(now.Month()))
-	if len(month) == 1 {
-		month = "0" + month
+	// User entered partial day (without month/year), in the base month
+	year := strconv.Itoa(base.Year())
+	month := strconv.Itoa(int(base.Month()))
+	if match := DayRegex.FindStringSubmatch(userInput); match != nil {
+		return dateFromStrings(year, month, match[1], "day of the same month as "+baseName)
 	}
-	if date, err := time.Parse("2006-01-02", year+"-"+month+"-"+userInput); err == nil {
-		return date, true
+
+	// User entered partial month-day (without year), in the base year
+	if match := MonthDayRegex.FindStringSubmatch(userInput); match != nil {
+		return dateFromStrings(year, match[1], match[2], "month and day of the same year as "+baseName)
 	}
 
-	// User entered partial month-day (without year)
-	if date, err := time.Parse("2006-01-02", year+"-"+userInput); err == nil {
-		return date, true
+	// User entered day/month (or month/day), with an optional year
+	if match := SlashDateRegex.FindStringSubmatch(userInput); match != nil {
+		day, month, description := match[1], match[2], "day/month"
+		if !dg.DayFirst {
+			day, month, description = match[2], match[1], "month/day"
+		}
+		if match[4] != "" {
+			return dateFromStrings(match[4], month, day, description+"/year")
+		}
+		return dateFromStrings(year, month, day, description+" of the same year as "+baseName)
 	}
 
 	// User entered -x, where x is a number of days
 	if match := SubtractDayRegex.MatchString(userInput); match {
 		numOfDays, _ := strconv.Atoi(userInput[1:])
-		return now.AddDate(0, 0, -numOfDays), true
+		return Interpretation{now.AddDate(0, 0, -numOfDays), pluralize(numOfDays, "day") + " ago"}, nil
+	}
+
+	// User entered +x, where x is a number of days
+	if match := AddDayRegex.MatchString(userInput); match {
+		numOfDays, _ := strconv.Atoi(userInput[1:])
+		return Interpretation{now.AddDate(0, 0, numOfDays), "in " + pluralize(numOfDays, "day")}, nil
+	}
+
+	// User entered `x days/weeks/months ago`
+	if match := AgoRegex.FindStringSubmatch(userInput); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		description := pluralize(n, match[2]) + " ago"
+		switch match[2] {
+		case "day":
+			return Interpretation{now.AddDate(0, 0, -n), description}, nil
+		case "week":
+			return Interpretation{now.AddDate(0, 0, -7*n), description}, nil
+		default:
+			return Interpretation{now.AddDate(0, -n, 0), description}, nil
+		}
 	}
 
-	return time.Time{}, false
+	// User entered a weekday, meaning the most recent one (maybe today)
+	if weekday, found := parseWeekday(userInput); found {
+		daysAgo := (int(now.Weekday()) - int(weekday) + 7) % 7
+		return Interpretation{now.AddDate(0, 0, -daysAgo), "most recent " + weekday.String()}, nil
+	}
+
+	// User entered `last` and a weekday, meaning the most recent one before today
+	if match := LastWeekdayRegex.FindStringSubmatch(userInput); match != nil {
+		if weekday, found := parseWeekday(match[1]); found {
+			daysAgo := (int(now.Weekday())-int(weekday)+6)%7 + 1
+			return Interpretation{now.AddDate(0, 0, -daysAgo), "last " + weekday.String()}, nil
+		}
+	}
+
+	return Interpretation{}, fmt.Errorf("unknown date: %s", userInput)
+}
+
+// dateFromStrings returns the date for a year, month and day, refusing
+// impossible dates (e.g. day 31 in a 30-day month).
+func dateFromStrings(yearStr, monthStr, dayStr, description string) (Interpretation, error) {
+	year, _ := strconv.Atoi(yearStr)
+	month, _ := strconv.Atoi(monthStr)
+	day, _ := strconv.Atoi(dayStr)
+	if month < 1 || month > 12 {
+		return Interpretation{}, fmt.Errorf("invalid month: %d", month)
+	}
+	// Day 0 of the next month is the last day of this month
+	lastDay := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day < 1 || day > lastDay {
+		return Interpretation{}, fmt.Errorf("invalid day: %s %d has %d days", time.Month(month), year, lastDay)
+	}
+	return Interpretation{time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), description}, nil
+}
+
+// parseWeekday parses a weekday name (e.g. `friday`) or its abbreviation
+// (e.g. `fri`).
+func parseWeekday(x string) (time.Weekday, bool) {
+	if len(x) < 3 {
+		return 0, false
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.HasPrefix(strings.ToLower(weekday.String()), x) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// pluralize returns a number of units, e.g. `1 day` or `2 days`.
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
 }
@@ -2,6 +2,7 @@ package dateguesser_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -25,7 +26,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "No input suggests today",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date1(t), guess)
 			},
@@ -34,7 +35,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "No input with statement entry suggests statement entry",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().AnyTimes().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("", finance.StatementEntry{Date: testutils.Date2(t)})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "", StatementEntry: finance.StatementEntry{Date: testutils.Date2(t)}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date2(t), guess)
 			},
@@ -43,7 +44,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Valid user input (full date)",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("1993-11-23", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "1993-11-23", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date1(t), guess)
 			},
@@ -52,7 +53,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Valid user input (day only)",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("23", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "23", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date1(t), guess)
 			},
@@ -61,7 +62,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Valid user input (day only, 1 digit month)",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().AnyTimes().Return(testutils.Date2(t))
-				guess, success := c.guesser.Guess("01", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "01", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date2(t), guess)
 			},
@@ -70,7 +71,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Valid user input (month and day)",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("11-23", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "11-23", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date1(t), guess)
 			},
@@ -79,7 +80,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Previous day",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("-1", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "-1", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date1(t).AddDate(0, 0, -1), guess)
 			},
@@ -88,7 +89,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Two days ago",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				guess, success := c.guesser.Guess("-2", finance.StatementEntry{})
+				guess, success := c.guesser.Guess(Inputs{UserInput: "-2", StatementEntry: finance.StatementEntry{}})
 				assert.True(t, success)
 				assert.Equal(t, testutils.Date1(t).AddDate(0, 0, -2), guess)
 			},
@@ -97,7 +98,7 @@ func TestDateGuesser(t *testing.T) {
 			name: "Invalid user input",
 			run: func(c *testcontext, t *testing.T) {
 				c.clock.EXPECT().Now().Return(testutils.Date1(t))
-				_, success := c.guesser.Guess("41", finance.StatementEntry{})
+				_, success := c.guesser.Guess(Inputs{UserInput: "41", StatementEntry: finance.StatementEntry{}})
 				assert.False(t, success)
 			},
 		},
@@ -108,8 +109,160 @@ func TestDateGuesser(t *testing.T) {
 			defer ctrl.Finish()
 			c := new(testcontext)
 			c.clock = NewMockIClock(ctrl)
-			c.guesser = &DateGuesser{c.clock}
+			c.guesser = &DateGuesser{Clock: c.clock, DayFirst: true}
 			tc.run(c, t)
 		})
 	}
 }
+
+func TestDateGuesserInterpret(t *testing.T) {
+	// A Tuesday
+	now := time.Date(1993, 11, 23, 0, 0, 0, 0, time.UTC)
+	date := func(year, month, day int) time.Time {
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	type testcase struct {
+		input       string
+		monthFirst  bool
+		date        time.Time
+		description string
+		errorMsg    string
+	}
+	var testcases = []testcase{
+		{input: "today", date: now, description: "today"},
+		{input: "Yesterday", date: date(1993, 11, 22), description: "yesterday"},
+		{input: "fri", date: date(1993, 11, 19), description: "most recent Friday"},
+		{input: "tuesday", date: now, description: "most recent Tuesday"},
+		{input: "last tue", date: date(1993, 11, 16), description: "last Tuesday"},
+		{input: "last monday", date: date(1993, 11, 22), description: "last Monday"},
+		{input: "+3", date: date(1993, 11, 26), description: "in 3 days"},
+		{input: "-3", date: date(1993, 11, 20), description: "3 days ago"},
+		{input: "2 weeks ago", date: date(1993, 11, 9), description: "2 weeks ago"},
+		{input: "1 month ago", date: date(1993, 10, 23), description: "1 month ago"},
+		{input: "27/09", date: date(1993, 9, 27), description: "day/month of the same year as today"},
+		{input: "09/27", monthFirst: true, date: date(1993, 9, 27), description: "month/day of the same year as today"},
+		{input: "27/09/2023", date: date(2023, 9, 27), description: "day/month/year"},
+		{input: "1993-2-3", date: date(1993, 2, 3), description: "full date"},
+		{input: "31", errorMsg: "invalid day: November 1993 has 30 days"},
+		{input: "1993-02-29", errorMsg: "invalid day: February 1993 has 28 days"},
+		{input: "09/27", errorMsg: "invalid month: 27"},
+		{input: "13-01", errorMsg: "invalid month: 13"},
+		{input: "last foo", errorMsg: "unknown date: last foo"},
+		{input: "fr", errorMsg: "unknown date: fr"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			clock := NewMockIClock(ctrl)
+			clock.EXPECT().Now().Return(now)
+			guesser := &DateGuesser{Clock: clock, DayFirst: !tc.monthFirst}
+			interpretation, err := guesser.Interpret(Inputs{UserInput: tc.input})
+			if tc.errorMsg != "" {
+				assert.ErrorContains(t, err, tc.errorMsg)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.date, interpretation.Date)
+			assert.Equal(t, tc.description, interpretation.Description)
+		})
+	}
+}
+
+func TestDateGuesserBase(t *testing.T) {
+	now := time.Date(1993, 11, 23, 0, 0, 0, 0, time.UTC)
+	statementDate := time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC)
+	lastTransactionDate := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	inputs := Inputs{
+		StatementEntry:      finance.StatementEntry{Date: statementDate},
+		LastTransactionDate: lastTransactionDate,
+	}
+	type testcase struct {
+		name        string
+		base        BaseStrategy
+		inputs      Inputs
+		date        time.Time
+		description string
+	}
+	var testcases = []testcase{
+		{
+			name:        "Statement base with no input",
+			base:        StatementBase,
+			inputs:      inputs,
+			date:        statementDate,
+			description: "date of the statement entry",
+		},
+		{
+			name:        "Statement base with day",
+			base:        StatementBase,
+			inputs:      Inputs{UserInput: "15", StatementEntry: inputs.StatementEntry},
+			date:        time.Date(2023, 9, 15, 0, 0, 0, 0, time.UTC),
+			description: "day of the same month as the statement entry",
+		},
+		{
+			name:        "Statement base without statement entry",
+			base:        StatementBase,
+			inputs:      Inputs{UserInput: "15", LastTransactionDate: lastTransactionDate},
+			date:        time.Date(1993, 11, 15, 0, 0, 0, 0, time.UTC),
+			description: "day of the same month as today",
+		},
+		{
+			name:        "Last transaction base with no input",
+			base:        LastTransactionBase,
+			inputs:      inputs,
+			date:        lastTransactionDate,
+			description: "date of the last transaction",
+		},
+		{
+			name:        "Last transaction base with month and day",
+			base:        LastTransactionBase,
+			inputs:      Inputs{UserInput: "02-03", LastTransactionDate: lastTransactionDate},
+			date:        time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC),
+			description: "month and day of the same year as the last transaction",
+		},
+		{
+			name:        "Today base ignores the statement entry",
+			base:        TodayBase,
+			inputs:      Inputs{UserInput: "15", StatementEntry: inputs.StatementEntry},
+			date:        time.Date(1993, 11, 15, 0, 0, 0, 0, time.UTC),
+			description: "day of the same month as today",
+		},
+		{
+			name:        "Relative dates are relative to today",
+			base:        LastTransactionBase,
+			inputs:      Inputs{UserInput: "-1", LastTransactionDate: lastTransactionDate},
+			date:        time.Date(1993, 11, 22, 0, 0, 0, 0, time.UTC),
+			description: "1 day ago",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			clock := NewMockIClock(ctrl)
+			clock.EXPECT().Now().Return(now)
+			guesser := &DateGuesser{Clock: clock, DayFirst: true, Base: tc.base}
+			interpretation, err := guesser.Interpret(tc.inputs)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.date, interpretation.Date)
+			assert.Equal(t, tc.description, interpretation.Description)
+		})
+	}
+}
+
+func TestParseBaseStrategy(t *testing.T) {
+	strategy, err := ParseBaseStrategy("last")
+	assert.Nil(t, err)
+	assert.Equal(t, LastTransactionBase, strategy)
+	_, err = ParseBaseStrategy("foo")
+	assert.ErrorContains(t, err, "invalid date guess base: foo")
+}
+
+func TestDayFirstLocale(t *testing.T) {
+	assert.True(t, DayFirstLocale("en_GB.UTF-8"))
+	assert.True(t, DayFirstLocale("pt_BR"))
+	assert.False(t, DayFirstLocale("en_US.UTF-8"))
+	assert.False(t, DayFirstLocale("es_US@euro"))
+	assert.True(t, DayFirstLocale("C"))
+	assert.True(t, DayFirstLocale(""))
+}
@@ -0,0 +1,39 @@
+package dateguesser
+
+import (
+	"os"
+	"strings"
+)
+
+// MonthFirstTerritories are the territories where dates are written with the
+// month before the day (e.g. `09/27`).
+var MonthFirstTerritories = []string{"US", "PH", "FM", "MH", "PW", "AS", "GU", "MP", "PR", "UM", "VI"}
+
+// Locale returns the user's locale for dates, from the environment.
+func Locale() string {
+	for _, variable := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if locale := os.Getenv(variable); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// DayFirstLocale returns whether dates are written with the day before the
+// month in a locale (e.g. `en_GB.UTF-8`). Unknown locales are day first.
+func DayFirstLocale(locale string) bool {
+	// Strips the encoding and modifier, e.g. `en_US.UTF-8@euro`
+	if i := strings.IndexAny(locale, ".@"); i != -1 {
+		locale = locale[:i]
+	}
+	parts := strings.Split(locale, "_")
+	if len(parts) != 2 {
+		return true
+	}
+	for _, territory := range MonthFirstTerritories {
+		if strings.EqualFold(parts[1], territory) {
+			return false
+		}
+	}
+	return true
+}
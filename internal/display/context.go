@@ -6,6 +6,7 @@ import (
 	"github.com/rivo/tview"
 	"github.com/vitorqb/addledger/internal/display/widgets"
 	eventbusmod "github.com/vitorqb/addledger/internal/eventbus"
+	"github.com/vitorqb/addledger/internal/journal"
 	statemod "github.com/vitorqb/addledger/internal/state"
 	"github.com/vitorqb/addledger/internal/userinput"
 )
@@ -81,6 +82,9 @@ func (c Context) MaybeSwitchToPage(pageName string) {
 }
 
 func NewAccountList(state *statemod.State, eventbus eventbusmod.IEventBus) (*widgets.ContextualList, error) {
+	// labels maps the items of the suggested accounts, which show their
+	// scores, back to the accounts.
+	labels := map[string]string{}
 	list, err := widgets.NewContextualList(widgets.ContextualListOptions{
 		GetItemsFunc: func() (out []string) {
 			// List all accounts
@@ -90,6 +94,9 @@ func NewAccountList(state *statemod.State, eventbus eventbusmod.IEventBus) (*wid
 			return out
 		},
 		SetSelectedFunc: func(s string) {
+			if account, found := labels[s]; found {
+				s = account
+			}
 			state.InputMetadata.SetSelectedPostingAccount(s)
 		},
 		GetInputFunc: func() string {
@@ -99,6 +106,32 @@ func NewAccountList(state *statemod.State, eventbus eventbusmod.IEventBus) (*wid
 			guess, success := state.InputMetadata.GetPostingAccountGuess()
 			return string(guess), success
 		},
+		// Without input, show the suggested accounts first (with their
+		// scores, if any), and then all other accounts.
+		EmptyInputAction: func(cl *widgets.ContextualList) {
+			candidates := state.InputMetadata.PostingAccountCandidates()
+			if len(candidates) == 0 {
+				widgets.EmptyInputActionShowAll(cl)
+				return
+			}
+			cl.Clear()
+			labels = map[string]string{}
+			suggested := map[journal.Account]bool{}
+			for _, candidate := range candidates {
+				label := string(candidate.Account)
+				if candidate.Scored {
+					label = fmt.Sprintf("%s (%.0f%%)", candidate.Account, candidate.Score*100)
+				}
+				labels[label] = string(candidate.Account)
+				suggested[candidate.Account] = true
+				cl.AddItem(label, "", 0, nil)
+			}
+			for _, acc := range state.JournalMetadata.Accounts() {
+				if !suggested[acc] {
+					cl.AddItem(string(acc), "", 0, nil)
+				}
+			}
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to build contextual list: %w", err)
@@ -118,10 +151,15 @@ func NewAccountList(state *statemod.State, eventbus eventbusmod.IEventBus) (*wid
 func NewDateGuesser(state *statemod.State) (*tview.TextView, error) {
 	guesser := tview.NewTextView()
 	refresh := func() {
+		interpretation := state.InputMetadata.DateInterpretation()
 		if guess, found := state.InputMetadata.GetDateGuess(); found {
-			guesser.SetText(guess.Format("2006-01-02") + "\n" + guess.Format("Mon, 02 Jan 2006"))
+			text := guess.Format("2006-01-02") + "\n" + guess.Format("Mon, 02 Jan 2006")
+			if interpretation != "" {
+				text += "\n(" + interpretation + ")"
+			}
+			guesser.SetText(text)
 		} else {
-			guesser.SetText("")
+			guesser.SetText(interpretation)
 		}
 	}
 	refresh()
@@ -3,6 +3,7 @@ package context
 import (
 	"github.com/rivo/tview"
 	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/userinput"
 )
 
 type AmmountGuesser struct {
@@ -21,6 +22,11 @@ func (ag *AmmountGuesser) Refresh() {
 	guess, found := ag.state.InputMetadata.GetPostingAmmountGuess()
 	if found {
 		newText := guess.Commodity + " " + guess.Quantity.String()
+		// Previews the evaluation of expressions
+		text := ag.state.InputMetadata.GetPostingAmmountText()
+		if _, expression := userinput.SplitCommodity(text); userinput.IsExpression(expression) {
+			newText = text + " = " + newText
+		}
 		ag.SetText(newText)
 	} else {
 		ag.SetText("")
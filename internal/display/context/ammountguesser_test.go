@@ -31,6 +31,24 @@ func TestAmmountGuesser(t *testing.T) {
 				assert.Equal(t, "EUR 12.2", text)
 			},
 		},
+		{
+			name: "Previews expressions",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.InputMetadata.SetPostingAmmountText("EUR 10.2+2")
+				c.state.InputMetadata.SetPostingAmmountGuess(anAmmount)
+				text := c.ammountGuesser.GetText(true)
+				assert.Equal(t, "EUR 10.2+2 = EUR 12.2", text)
+			},
+		},
+		{
+			name: "Does not preview plain numbers",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.InputMetadata.SetPostingAmmountText("12.2")
+				c.state.InputMetadata.SetPostingAmmountGuess(anAmmount)
+				text := c.ammountGuesser.GetText(true)
+				assert.Equal(t, "EUR 12.2", text)
+			},
+		},
 		{
 			name: "Loads guess from state",
 			run: func(t *testing.T, c *testcontext) {
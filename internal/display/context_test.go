@@ -6,6 +6,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
+	"github.com/vitorqb/addledger/internal/accountguesser"
 	. "github.com/vitorqb/addledger/internal/display"
 	"github.com/vitorqb/addledger/internal/display/widgets"
 	"github.com/vitorqb/addledger/internal/journal"
@@ -92,6 +93,22 @@ func TestNewDateGuesser(t *testing.T) {
 				assert.Equal(t, expectedDate1String, c.guesser.GetText(true))
 			},
 		},
+		{
+			name: "shows interpretation",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.InputMetadata.SetDateInterpretation("yesterday")
+				c.state.InputMetadata.SetDateGuess(testutils.Date1(t))
+				assert.Equal(t, expectedDate1String+"\n(yesterday)", c.guesser.GetText(true))
+			},
+		},
+		{
+			name: "shows why there is no guess",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.InputMetadata.ClearDateGuess()
+				c.state.InputMetadata.SetDateInterpretation("invalid day: November 1993 has 30 days")
+				assert.Equal(t, "invalid day: November 1993 has 30 days", c.guesser.GetText(true))
+			},
+		},
 		{
 			name: "clears when state clears",
 			run: func(c *testcontext, t *testing.T) {
@@ -137,6 +154,27 @@ func TestAccountList(t *testing.T) {
 				assert.Equal(t, string(anAccount), c.state.InputMetadata.SelectedPostingAccount())
 			},
 		},
+		{
+			name: "Shows the suggested accounts first, with their scores if any",
+			setup: func(c *testcontext) {
+				c.state.JournalMetadata.SetAccounts([]journal.Account{"assets:bank", "expenses:food", "expenses:rent"})
+				c.state.InputMetadata.SetPostingAccountCandidates([]accountguesser.Candidate{
+					{Account: "expenses:rent"},
+					{Account: "expenses:food", Score: 0.2, Scored: true},
+				})
+				c.state.InputMetadata.SetPostingAccountGuess("expenses:rent")
+			},
+			run: func(c *testcontext, t *testing.T) {
+				assert.Equal(t, 3, c.accountList.GetItemCount())
+				first, _ := c.accountList.GetItemText(0)
+				assert.Equal(t, "expenses:rent", first)
+				second, _ := c.accountList.GetItemText(1)
+				assert.Equal(t, "expenses:food (20%)", second)
+				third, _ := c.accountList.GetItemText(2)
+				assert.Equal(t, "assets:bank", third)
+				assert.Equal(t, "expenses:rent", c.state.InputMetadata.SelectedPostingAccount())
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
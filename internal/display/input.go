@@ -97,6 +97,10 @@ func (i *Input) refresh() {
 		if i.CurrentPageName() != string(INPUT_POSTING_ACCOUNT) {
 			i.postingAccountField.SetText("")
 			i.SwitchToPage(string(INPUT_POSTING_ACCOUNT))
+		} else if i.state.InputMetadata.PostingAccountText() == "" && i.postingAccountField.GetText() != "" {
+			// Going to the account of the next posting (e.g. from an even
+			// split) without leaving the phase.
+			i.postingAccountField.SetText("")
 		}
 	case statemod.InputPostingAmmount:
 		if i.CurrentPageName() != string(INPUT_POSTING_AMMOUNT) {
@@ -118,6 +122,13 @@ func DescriptionField(
 	inputField := widgets.NewInputField()
 	inputField.SetLabel("Description: ")
 	inputField.SetChangedFunc(controller.OnDescriptionChanged)
+	inputField.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Modifiers() != tcell.ModAlt || event.Key() != tcell.KeyEnter {
+			return event
+		}
+		controller.OnDescriptionFillFromMatch()
+		return nil
+	})
 	inputField.LinkContextualList(eventbus, widgets.ContextualListLinkOpts{
 		InputName:           "description",
 		OnListAction:        controller.OnDescriptionListAction,
@@ -95,6 +95,17 @@ func TestDescriptionField(t *testing.T) {
 				field.InputHandler()(event, fakeSetFocus)
 			},
 		},
+		{
+			name: "Dispatches fill from match to controller on alt+Enter",
+			run: func(c *testcontext, t *testing.T) {
+				c.controller.EXPECT().OnDescriptionFillFromMatch()
+				c.eventbus.EXPECT().Subscribe(gomock.Any())
+				field := DescriptionField(c.controller, c.eventbus)
+
+				event := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModAlt)
+				field.InputHandler()(event, fakeSetFocus)
+			},
+		},
 		{
 			name: "Set text from topic",
 			run: func(c *testcontext, t *testing.T) {
@@ -243,6 +254,21 @@ func TestInput(t *testing.T) {
 				assert.Equal(t, "Tags: ", field.GetLabel())
 			},
 		},
+		{
+			name: "Clears the account for the next posting",
+			run: func(c *testcontext, t *testing.T) {
+				c.controller.EXPECT().OnPostingAccountChanged(gomock.Any()).AnyTimes()
+				c.state.SetPhase(statemod.InputPostingAccount)
+				_, page := c.input.GetFrontPage()
+				field, ok := page.(*widgets.InputField)
+				assert.True(t, ok)
+				field.SetText("FOO")
+				c.state.InputMetadata.SetPostingAccountText("FOO")
+				assert.Equal(t, "FOO", field.GetText())
+				c.state.InputMetadata.SetPostingAccountText("")
+				assert.Equal(t, "", field.GetText())
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -23,6 +23,24 @@ func (s *StatementControllerAdapter) LoadRequest() { s.OnLoadStatementRequest()
 func (s *StatementControllerAdapter) DiscardStatementEntry(index int) {
 	s.OnDiscardStatementEntry(index)
 }
+func (s *StatementControllerAdapter) DiscardDuplicatedStatementEntries() {
+	s.OnDiscardDuplicatedStatementEntries()
+}
+func (s *StatementControllerAdapter) UnloadStatementSource(index int) {
+	s.OnUnloadStatementSource(index)
+}
+func (s *StatementControllerAdapter) UseStatementEntry(index int) {
+	s.OnSelectStatementEntry(index)
+}
+func (s *StatementControllerAdapter) EditStatementEntry(index int) {
+	s.OnEditStatementEntry(index)
+}
+func (s *StatementControllerAdapter) UpdateStatementEntry(index int, account, date, description, ammount string) {
+	s.OnUpdateStatementEntry(index, account, date, description, ammount)
+}
+func (s *StatementControllerAdapter) CancelStatementEntryEdit() {
+	s.OnCancelStatementEntryEdit()
+}
 
 type (
 	// MainView represents the main view of the application, which contains
@@ -49,9 +67,12 @@ type (
 	// and other modal views (such as the ShortcutModal).
 	Layout struct {
 		*tview.Pages
-		mainView *MainView
-		state    *state.State
-		setFocus func(p tview.Primitive) *tview.Application
+		mainView             *MainView
+		statementModal       tview.Primitive
+		resumeStatementModal *ResumeStatementModal
+		presetBuilder        *PresetBuilder
+		state                *state.State
+		setFocus             func(p tview.Primitive) *tview.Application
 	}
 
 	// MessageBox is a tview.TextView that displays messages to the user.
@@ -69,10 +90,12 @@ type (
 type LayoutPage string
 
 var (
-	MainPage               LayoutPage = "main"
-	ShortcutModalPage      LayoutPage = "shortcutModal"
-	StatementModalPage     LayoutPage = "statementModal"
-	LoadStatementModalPage LayoutPage = "loadStatementModal"
+	MainPage                 LayoutPage = "main"
+	ShortcutModalPage        LayoutPage = "shortcutModal"
+	StatementModalPage       LayoutPage = "statementModal"
+	LoadStatementModalPage   LayoutPage = "loadStatementModal"
+	ResumeStatementModalPage LayoutPage = "resumeStatementModal"
+	PresetBuilderPage        LayoutPage = "presetBuilder"
 )
 
 const (
@@ -180,21 +203,28 @@ func NewLayout(
 	shortcutModal := center(NewShortcutModal(controller), modalWith, modalHeight)
 	loadStatementModal := center(NewLoadStatementModal(controller, state.Display.StatementModal), modalWith*2, modalHeight*2)
 	statementModal := center(statement.NewModal(&StatementControllerAdapter{controller}, state), modalWith*3, modalHeight*3)
+	resumeStatementModal := NewResumeStatementModal(controller)
+	presetBuilder := NewPresetBuilder(controller, state.Display.StatementModal)
 
 	pages := tview.NewPages()
 	pages.AddAndSwitchToPage(string(MainPage), mainView, true)
 	pages.AddPage(string(ShortcutModalPage), shortcutModal, true, false)
 	pages.AddPage(string(LoadStatementModalPage), loadStatementModal, true, false)
 	pages.AddPage(string(StatementModalPage), statementModal, true, false)
+	pages.AddPage(string(ResumeStatementModalPage), resumeStatementModal, true, false)
+	pages.AddPage(string(PresetBuilderPage), presetBuilder, true, false)
 	pages.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		return HandleGlobalShortcuts(controller, event)
 	})
 
 	layout := &Layout{
-		Pages:    pages,
-		mainView: mainView,
-		state:    state,
-		setFocus: app.SetFocus,
+		Pages:                pages,
+		mainView:             mainView,
+		statementModal:       statementModal,
+		resumeStatementModal: resumeStatementModal,
+		presetBuilder:        presetBuilder,
+		state:                state,
+		setFocus:             app.SetFocus,
 	}
 	layout.Refresh()
 	state.AddOnChangeHook(layout.Refresh)
@@ -219,9 +249,16 @@ func (l *Layout) Refresh() {
 	l.refreshShortcutModalDisplay()
 	l.refreshLoadStatementModalDisplay()
 	l.refreshStatementModalDisplay()
+	l.refreshResumeStatementModalDisplay()
+	l.refreshPresetBuilderDisplay()
 	// Make sure that once the modal is hidden we focus back on the main view.
-	if frontPage, _ := l.GetFrontPage(); frontPage == string(MainPage) {
+	// The statement modal is also refocused, since it swaps its content when
+	// editing an entry.
+	switch frontPage, _ := l.GetFrontPage(); frontPage {
+	case string(MainPage):
 		l.setFocus(l.mainView)
+	case string(StatementModalPage):
+		l.setFocus(l.statementModal)
 	}
 }
 
@@ -249,6 +286,24 @@ func (l *Layout) refreshStatementModalDisplay() {
 	l.HidePage(string(StatementModalPage))
 }
 
+func (l *Layout) refreshResumeStatementModalDisplay() {
+	if progress, found := l.state.ResumableStatementProgress(); found {
+		l.resumeStatementModal.SetProgress(len(progress.Remaining), len(progress.Discarded))
+		l.ShowPage(string(ResumeStatementModalPage))
+		return
+	}
+	l.HidePage(string(ResumeStatementModalPage))
+}
+
+func (l *Layout) refreshPresetBuilderDisplay() {
+	if l.state.Display.PresetBuilder.Visible() {
+		l.presetBuilder.SetPreview(l.state.Display.PresetBuilder.Preview())
+		l.ShowPage(string(PresetBuilderPage))
+		return
+	}
+	l.HidePage(string(PresetBuilderPage))
+}
+
 func HandleGlobalShortcuts(controller controller.IInputController, event *tcell.EventKey) *tcell.EventKey {
 	if event == nil {
 		return nil
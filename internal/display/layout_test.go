@@ -111,6 +111,38 @@ func TestNewLayout(t *testing.T) {
 				assert.True(t, c.layout.InputHasFocus())
 			},
 		},
+		{
+			name: "Displays and hides the resume statement modal",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.SetResumableStatementProgress("file", statemod.StatementProgress{})
+				c.layout.Refresh()
+				frontPage, _ := c.layout.GetFrontPage()
+				assert.Equal(t, string(ResumeStatementModalPage), frontPage)
+				assert.False(t, c.layout.InputHasFocus())
+
+				c.state.ClearResumableStatementProgress()
+				c.layout.Refresh()
+				frontPage, _ = c.layout.GetFrontPage()
+				assert.Equal(t, string(MainPage), frontPage)
+				assert.True(t, c.layout.InputHasFocus())
+			},
+		},
+		{
+			name: "Displays and hides the preset builder",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.Display.PresetBuilder.SetVisible(true)
+				c.layout.Refresh()
+				frontPage, _ := c.layout.GetFrontPage()
+				assert.Equal(t, string(PresetBuilderPage), frontPage)
+				assert.False(t, c.layout.InputHasFocus())
+
+				c.state.Display.PresetBuilder.SetVisible(false)
+				c.layout.Refresh()
+				frontPage, _ = c.layout.GetFrontPage()
+				assert.Equal(t, string(MainPage), frontPage)
+				assert.True(t, c.layout.InputHasFocus())
+			},
+		},
 		{
 			name: "Displays a given message",
 			run: func(c *testcontext, t *testing.T) {
@@ -13,7 +13,7 @@ type (
 	}
 
 	LoadStatementModalController interface {
-		OnLoadStatement(csvFile string, presetFile string)
+		OnLoadStatement(csvFile string, presetFile string, format string)
 	}
 
 	State interface {
@@ -23,6 +23,7 @@ type (
 
 const csvFileLabel = "CSV File"
 const presetLabel = "Preset"
+const formatLabel = "Format"
 
 func NewLoadStatementModal(
 	controller LoadStatementModalController,
@@ -33,10 +34,12 @@ func NewLoadStatementModal(
 	form.SetTitle("Load Statement")
 	form.AddInputField(csvFileLabel, state.DefaultCsvFile(), 0, nil, nil)
 	form.AddInputField(presetLabel, "", 0, nil, nil)
+	form.AddInputField(formatLabel, "", 0, nil, nil)
 	form.AddButton("Load", func() {
 		csvFileField := form.GetCsvInput().GetText()
 		presetField := form.GetPresetInput().GetText()
-		controller.OnLoadStatement(csvFileField, presetField)
+		formatField := form.GetFormatInput().GetText()
+		controller.OnLoadStatement(csvFileField, presetField, formatField)
 	})
 	return form
 }
@@ -48,3 +51,7 @@ func (l *LoadStatementModal) GetCsvInput() *tview.InputField {
 func (l *LoadStatementModal) GetPresetInput() *tview.InputField {
 	return l.GetFormItemByLabel(presetLabel).(*tview.InputField)
 }
+
+func (l *LoadStatementModal) GetFormatInput() *tview.InputField {
+	return l.GetFormItemByLabel(formatLabel).(*tview.InputField)
+}
@@ -24,13 +24,15 @@ func TestLoadStatementModal(t *testing.T) {
 		{
 			name: "Calls controller on load",
 			run: func(t *testing.T, c *testcontext) {
-				c.controller.EXPECT().OnLoadStatement("file", "preset").Times(1)
+				c.controller.EXPECT().OnLoadStatement("file", "preset", "camt053").Times(1)
 				c.state.EXPECT().DefaultCsvFile().Return("/foo")
 				loadStatementModal := NewLoadStatementModal(c.controller, c.state)
 				csvFileField := loadStatementModal.GetCsvInput()
 				csvFileField.SetText("file")
 				presetField := loadStatementModal.GetPresetInput()
 				presetField.SetText("preset")
+				formatField := loadStatementModal.GetFormatInput()
+				formatField.SetText("camt053")
 				enterEvent := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone)
 				loadStatementModal.GetButton(0).InputHandler()(enterEvent, func(tview.Primitive) {})
 
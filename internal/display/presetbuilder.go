@@ -0,0 +1,200 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vitorqb/addledger/internal/display/statement"
+	"github.com/vitorqb/addledger/internal/services/statementloader"
+	"github.com/vitorqb/addledger/internal/state"
+)
+
+//go:generate $MOCKGEN --source=presetbuilder.go --destination=../../mocks/display/presetbuilder_mock.go
+
+type (
+	// PresetBuilder is a screen to build a statement preset. The user fills
+	// a form describing the columns of a csv file, and sees the raw rows of
+	// the file and the statement entries read with the preset as they type.
+	PresetBuilder struct {
+		*tview.Flex
+		form         *tview.Form
+		rowsTable    *tview.Table
+		previewTable *tview.Table
+		controller   PresetBuilderController
+	}
+
+	PresetBuilderController interface {
+		OnPresetBuilderChanged(config statementloader.Config)
+		OnSavePreset(name string, config statementloader.Config)
+		OnHidePresetBuilder()
+	}
+)
+
+const presetFileLabel = "File"
+const presetEncodingLabel = "Encoding"
+const presetSeparatorLabel = "Separator"
+const presetSkipLabel = "Skip rows"
+const presetDateColumnLabel = "Date column"
+const presetDateFormatLabel = "Date format"
+const presetDescriptionColumnLabel = "Description column"
+const presetAmmountColumnLabel = "Ammount column"
+const presetAccountColumnLabel = "Account column"
+const presetAccountLabel = "Account"
+const presetCommodityLabel = "Commodity"
+const presetInvertSignLabel = "Invert sign"
+const presetNameLabel = "Preset name"
+
+func NewPresetBuilder(controller PresetBuilderController, state State) *PresetBuilder {
+	builder := &PresetBuilder{
+		Flex:         tview.NewFlex(),
+		form:         tview.NewForm(),
+		rowsTable:    tview.NewTable(),
+		previewTable: tview.NewTable(),
+		controller:   controller,
+	}
+	changed := func(string) { builder.changed() }
+	builder.form.SetBorder(true)
+	builder.form.SetTitle("Preset Builder")
+	builder.form.AddInputField(presetFileLabel, state.DefaultCsvFile(), 0, nil, changed)
+	builder.form.AddInputField(presetEncodingLabel, "", 0, nil, changed)
+	builder.form.AddInputField(presetSeparatorLabel, ",", 0, nil, changed)
+	builder.form.AddInputField(presetSkipLabel, "0", 0, tview.InputFieldInteger, changed)
+	builder.form.AddInputField(presetDateColumnLabel, "", 0, tview.InputFieldInteger, changed)
+	builder.form.AddInputField(presetDateFormatLabel, "02/01/2006", 0, nil, changed)
+	builder.form.AddInputField(presetDescriptionColumnLabel, "", 0, tview.InputFieldInteger, changed)
+	builder.form.AddInputField(presetAmmountColumnLabel, "", 0, tview.InputFieldInteger, changed)
+	builder.form.AddInputField(presetAccountColumnLabel, "", 0, tview.InputFieldInteger, changed)
+	builder.form.AddInputField(presetAccountLabel, "", 0, nil, changed)
+	builder.form.AddInputField(presetCommodityLabel, "", 0, nil, changed)
+	builder.form.AddCheckbox(presetInvertSignLabel, false, func(bool) { builder.changed() })
+	builder.form.AddInputField(presetNameLabel, "", 0, nil, nil)
+	builder.form.AddButton("Save", func() {
+		controller.OnSavePreset(builder.GetNameInput().GetText(), builder.Config())
+	})
+	builder.form.AddButton("Close", controller.OnHidePresetBuilder)
+	builder.form.SetCancelFunc(controller.OnHidePresetBuilder)
+
+	builder.rowsTable.SetBorder(true)
+	builder.rowsTable.SetTitle("Statement rows")
+	builder.rowsTable.SetFixed(1, 0)
+	builder.previewTable.SetBorder(true)
+	builder.previewTable.SetFixed(1, 0)
+
+	tables := tview.NewFlex().SetDirection(tview.FlexRow)
+	tables.AddItem(builder.rowsTable, 0, 1, false)
+	tables.AddItem(builder.previewTable, 0, 1, false)
+	builder.AddItem(builder.form, 50, 0, true)
+	builder.AddItem(tables, 0, 1, false)
+	return builder
+}
+
+// Focus always focuses on the form, since the tables are only a preview.
+func (b *PresetBuilder) Focus(delegate func(p tview.Primitive)) {
+	delegate(b.form)
+}
+
+// changed notifies the controller that the preset being built changed.
+func (b *PresetBuilder) changed() {
+	b.controller.OnPresetBuilderChanged(b.Config())
+}
+
+// Config returns the statement loader config described by the form.
+func (b *PresetBuilder) Config() statementloader.Config {
+	skip, _ := strconv.Atoi(b.getInput(presetSkipLabel).GetText())
+	return statementloader.Config{
+		File:                  b.GetFileInput().GetText(),
+		Encoding:              b.getInput(presetEncodingLabel).GetText(),
+		Separator:             b.getInput(presetSeparatorLabel).GetText(),
+		Skip:                  skip,
+		DateFieldIndex:        columnIndex(b.getInput(presetDateColumnLabel).GetText()),
+		DateFormat:            b.getInput(presetDateFormatLabel).GetText(),
+		DescriptionFieldIndex: columnIndex(b.getInput(presetDescriptionColumnLabel).GetText()),
+		AmmountFieldIndex:     columnIndex(b.getInput(presetAmmountColumnLabel).GetText()),
+		AccountFieldIndex:     columnIndex(b.getInput(presetAccountColumnLabel).GetText()),
+		AmmountInFieldIndex:   -1,
+		AmmountOutFieldIndex:  -1,
+		InvertSignFieldIndex:  -1,
+		CommodityFieldIndex:   -1,
+		Account:               b.getInput(presetAccountLabel).GetText(),
+		Commodity:             b.getInput(presetCommodityLabel).GetText(),
+		InvertSign:            b.GetInvertSignCheckbox().IsChecked(),
+	}
+}
+
+// columnIndex parses the index of a column, returning -1 if not set.
+func columnIndex(text string) int {
+	index, err := strconv.Atoi(text)
+	if err != nil || index < 0 {
+		return -1
+	}
+	return index
+}
+
+// SetPreview displays the raw rows of the file and the entries read with
+// the preset being built.
+func (b *PresetBuilder) SetPreview(preview state.PresetPreview) {
+	b.rowsTable.Clear()
+	for i, row := range preview.Rows {
+		for j, value := range row {
+			if i == 0 {
+				header := tview.NewTableCell(strconv.Itoa(j)).SetTextColor(tcell.ColorYellow)
+				b.rowsTable.SetCell(0, j, header)
+			}
+			b.rowsTable.SetCell(i+1, j, tview.NewTableCell(value))
+		}
+	}
+
+	b.previewTable.Clear()
+	for j, header := range []string{"Date", "Description", "Account", "Ammount"} {
+		b.previewTable.SetCell(0, j, tview.NewTableCell(header).SetTextColor(tcell.ColorYellow))
+	}
+	for i, entry := range preview.Entries {
+		ammount := strings.TrimSpace(entry.Ammount.Commodity + " " + entry.Ammount.Quantity.String())
+		b.previewTable.SetCell(i+1, 0, tview.NewTableCell(entry.Date.Format(statement.DateFormat)))
+		b.previewTable.SetCell(i+1, 1, tview.NewTableCell(entry.Description))
+		b.previewTable.SetCell(i+1, 2, tview.NewTableCell(entry.Account))
+		b.previewTable.SetCell(i+1, 3, tview.NewTableCell(ammount))
+	}
+	if preview.Err != nil {
+		b.previewTable.SetTitle(fmt.Sprintf("Preview: [red]%s", tview.Escape(preview.Err.Error())))
+		return
+	}
+	b.previewTable.SetTitle("Preview")
+}
+
+func (b *PresetBuilder) getInput(label string) *tview.InputField {
+	return b.form.GetFormItemByLabel(label).(*tview.InputField)
+}
+
+func (b *PresetBuilder) GetForm() *tview.Form { return b.form }
+
+func (b *PresetBuilder) GetFileInput() *tview.InputField { return b.getInput(presetFileLabel) }
+
+func (b *PresetBuilder) GetSeparatorInput() *tview.InputField {
+	return b.getInput(presetSeparatorLabel)
+}
+
+func (b *PresetBuilder) GetDateColumnInput() *tview.InputField {
+	return b.getInput(presetDateColumnLabel)
+}
+
+func (b *PresetBuilder) GetDescriptionColumnInput() *tview.InputField {
+	return b.getInput(presetDescriptionColumnLabel)
+}
+
+func (b *PresetBuilder) GetAmmountColumnInput() *tview.InputField {
+	return b.getInput(presetAmmountColumnLabel)
+}
+
+func (b *PresetBuilder) GetInvertSignCheckbox() *tview.Checkbox {
+	return b.form.GetFormItemByLabel(presetInvertSignLabel).(*tview.Checkbox)
+}
+
+func (b *PresetBuilder) GetNameInput() *tview.InputField { return b.getInput(presetNameLabel) }
+
+func (b *PresetBuilder) GetRowsTable() *tview.Table { return b.rowsTable }
+
+func (b *PresetBuilder) GetPreviewTable() *tview.Table { return b.previewTable }
@@ -0,0 +1,122 @@
+package display_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rivo/tview"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/display"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/services/statementloader"
+	statemod "github.com/vitorqb/addledger/internal/state"
+	. "github.com/vitorqb/addledger/mocks/display"
+)
+
+func TestPresetBuilder(t *testing.T) {
+	type testcontext struct {
+		controller *MockPresetBuilderController
+		state      *MockState
+		builder    *PresetBuilder
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, c *testcontext)
+	}
+	var testcases = []testcase{
+		{
+			name: "Uses default path from state",
+			run: func(t *testing.T, c *testcontext) {
+				assert.Equal(t, "/foo.csv", c.builder.GetFileInput().GetText())
+			},
+		},
+		{
+			name: "Calls controller with the config on change",
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.EXPECT().OnPresetBuilderChanged(statementloader.Config{
+					File:                  "/foo.csv",
+					Separator:             ";",
+					DateFieldIndex:        0,
+					DateFormat:            "02/01/2006",
+					DescriptionFieldIndex: 2,
+					AmmountFieldIndex:     -1,
+					AccountFieldIndex:     -1,
+					AmmountInFieldIndex:   -1,
+					AmmountOutFieldIndex:  -1,
+					InvertSignFieldIndex:  -1,
+					CommodityFieldIndex:   -1,
+					InvertSign:            true,
+				}).Times(1)
+				c.controller.EXPECT().OnPresetBuilderChanged(gomock.Any()).Times(3)
+				c.builder.GetSeparatorInput().SetText(";")
+				c.builder.GetDateColumnInput().SetText("0")
+				c.builder.GetDescriptionColumnInput().SetText("2")
+				enterEvent := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone)
+				c.builder.GetInvertSignCheckbox().InputHandler()(enterEvent, func(tview.Primitive) {})
+			},
+		},
+		{
+			name: "Calls controller on save",
+			run: func(t *testing.T, c *testcontext) {
+				c.builder.GetNameInput().SetText("mybank")
+				c.controller.EXPECT().OnSavePreset("mybank", gomock.Any()).Times(1)
+				enterEvent := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone)
+				c.builder.GetForm().GetButton(0).InputHandler()(enterEvent, func(tview.Primitive) {})
+			},
+		},
+		{
+			name: "Calls controller on close",
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.EXPECT().OnHidePresetBuilder().Times(1)
+				enterEvent := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone)
+				c.builder.GetForm().GetButton(1).InputHandler()(enterEvent, func(tview.Primitive) {})
+			},
+		},
+		{
+			name: "Displays the preview",
+			run: func(t *testing.T, c *testcontext) {
+				c.builder.SetPreview(statemod.PresetPreview{
+					Rows: [][]string{{"2023-01-02", "FOO", "12.50"}},
+					Entries: []finance.StatementEntry{{
+						Date:        time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+						Description: "FOO",
+						Account:     "ACC",
+						Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1250, -2)},
+					}},
+				})
+				rows := c.builder.GetRowsTable()
+				assert.Equal(t, "2", rows.GetCell(0, 2).Text)
+				assert.Equal(t, "FOO", rows.GetCell(1, 1).Text)
+				preview := c.builder.GetPreviewTable()
+				assert.Equal(t, "Preview", preview.GetTitle())
+				assert.Equal(t, "2023-01-02", preview.GetCell(1, 0).Text)
+				assert.Equal(t, "FOO", preview.GetCell(1, 1).Text)
+				assert.Equal(t, "ACC", preview.GetCell(1, 2).Text)
+				assert.Equal(t, "EUR 12.5", preview.GetCell(1, 3).Text)
+			},
+		},
+		{
+			name: "Displays the preview error",
+			run: func(t *testing.T, c *testcontext) {
+				c.builder.SetPreview(statemod.PresetPreview{Err: assert.AnError})
+				assert.Contains(t, c.builder.GetPreviewTable().GetTitle(), assert.AnError.Error())
+				assert.Equal(t, 1, c.builder.GetPreviewTable().GetRowCount())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := new(testcontext)
+			c.controller = NewMockPresetBuilderController(ctrl)
+			c.state = NewMockState(ctrl)
+			c.state.EXPECT().DefaultCsvFile().Return("/foo.csv")
+			c.builder = NewPresetBuilder(c.controller, c.state)
+			tc.run(t, c)
+		})
+	}
+}
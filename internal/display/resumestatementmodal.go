@@ -0,0 +1,44 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+//go:generate $MOCKGEN --source=resumestatementmodal.go --destination=../../mocks/display/resumestatementmodal_mock.go
+
+type (
+	// ResumeStatementModal asks the user whether to resume the saved progress
+	// of a loaded statement or to start over.
+	ResumeStatementModal struct {
+		*tview.Modal
+		controller ResumeStatementModalController
+	}
+
+	ResumeStatementModalController interface {
+		OnResumeStatement(resume bool)
+	}
+)
+
+const resumeButtonLabel = "Resume"
+const startOverButtonLabel = "Start over"
+
+func NewResumeStatementModal(controller ResumeStatementModalController) *ResumeStatementModal {
+	modal := &ResumeStatementModal{tview.NewModal(), controller}
+	modal.AddButtons([]string{resumeButtonLabel, startOverButtonLabel})
+	modal.SetDoneFunc(func(_ int, buttonLabel string) {
+		modal.controller.OnResumeStatement(buttonLabel == resumeButtonLabel)
+	})
+	modal.SetProgress(0, 0)
+	return modal
+}
+
+// SetProgress updates the modal text with the saved progress.
+func (m *ResumeStatementModal) SetProgress(remaining, discarded int) {
+	m.SetText(fmt.Sprintf(
+		"This statement was partially imported before (%d entries remaining, %d discarded). Resume?",
+		remaining,
+		discarded,
+	))
+}
@@ -0,0 +1,71 @@
+package display_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/display"
+	"github.com/vitorqb/addledger/internal/testutils"
+	. "github.com/vitorqb/addledger/mocks/display"
+)
+
+func TestResumeStatementModal(t *testing.T) {
+	type testcontext struct {
+		controller *MockResumeStatementModalController
+		modal      *ResumeStatementModal
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, c *testcontext)
+	}
+	enterEvent := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone)
+	var setFocus func(p tview.Primitive)
+	setFocus = func(p tview.Primitive) { p.Focus(setFocus) }
+	var testcases = []testcase{
+		{
+			name: "Resumes",
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.EXPECT().OnResumeStatement(true)
+				c.modal.Focus(setFocus)
+				c.modal.InputHandler()(enterEvent, setFocus)
+			},
+		},
+		{
+			name: "Starts over",
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.EXPECT().OnResumeStatement(false)
+				c.modal.SetFocus(1)
+				c.modal.Focus(setFocus)
+				c.modal.InputHandler()(enterEvent, setFocus)
+			},
+		},
+		{
+			name: "Displays progress",
+			run: func(t *testing.T, c *testcontext) {
+				c.modal.SetProgress(3, 2)
+				ss := tcell.NewSimulationScreen("UTF-8")
+				_ = ss.Init()
+				ss.SetSize(200, 20)
+				c.modal.SetRect(0, 0, 200, 20)
+				c.modal.Draw(ss)
+				ss.Sync()
+				text := testutils.ExtractText(ss)
+				assert.Contains(t, text, "(3 entries")
+				assert.Contains(t, text, "remaining, 2 discarded")
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := new(testcontext)
+			c.controller = NewMockResumeStatementModalController(ctrl)
+			c.modal = NewResumeStatementModal(c.controller)
+			tc.run(t, c)
+		})
+	}
+}
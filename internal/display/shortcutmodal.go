@@ -13,6 +13,8 @@ type ShortcutModalController interface {
 	OnHideShortcutModal()
 	// Displays the statement modal
 	OnShowStatementModal()
+	// Displays the preset builder
+	OnShowPresetBuilder()
 }
 
 type ShortcutModal struct {
@@ -23,6 +25,7 @@ type ShortcutModal struct {
 func getBodyText() string {
 	return strings.Trim(
 		"s - Statement modal\n"+
+			"p - Preset builder\n"+
 			"q - Quit\n",
 		"\n",
 	)
@@ -40,6 +43,9 @@ func NewShortcutModal(controller ShortcutModalController) *ShortcutModal {
 			case 's':
 				modal.controller.OnShowStatementModal()
 				modal.controller.OnHideShortcutModal()
+			case 'p':
+				modal.controller.OnShowPresetBuilder()
+				modal.controller.OnHideShortcutModal()
 			case 'q':
 				modal.controller.OnHideShortcutModal()
 				return nil
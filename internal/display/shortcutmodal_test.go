@@ -51,6 +51,13 @@ func TestShortcutModal(t *testing.T) {
 				c.EXPECT().OnHideShortcutModal().Times(1)
 			}),
 		},
+		{
+			name: "Calls show preset builder",
+			run: testExpectOnKey(tcell.KeyRune, 'p', tcell.ModNone, func(c *MockShortcutModalController) {
+				c.EXPECT().OnShowPresetBuilder().Times(1)
+				c.EXPECT().OnHideShortcutModal().Times(1)
+			}),
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,68 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/vitorqb/addledger/internal/finance"
+)
+
+const accountLabel = "Account"
+const dateLabel = "Date"
+const descriptionLabel = "Description"
+const ammountLabel = "Ammount"
+
+// EditForm is a form to edit a statement entry.
+type EditForm struct {
+	*tview.Form
+	// index is the index of the statement entry being edited.
+	index int
+}
+
+// SetEntry fills the form with the statement entry being edited.
+func (f *EditForm) SetEntry(index int, entry finance.StatementEntry) {
+	f.index = index
+	f.GetAccountInput().SetText(entry.Account)
+	f.GetDateInput().SetText(entry.Date.Format(DateFormat))
+	f.GetDescriptionInput().SetText(entry.Description)
+	f.GetAmmountInput().SetText(strings.TrimSpace(entry.Ammount.Commodity + " " + entry.Ammount.Quantity.String()))
+	f.SetFocus(0)
+}
+
+func (f *EditForm) GetAccountInput() *tview.InputField {
+	return f.GetFormItemByLabel(accountLabel).(*tview.InputField)
+}
+
+func (f *EditForm) GetDateInput() *tview.InputField {
+	return f.GetFormItemByLabel(dateLabel).(*tview.InputField)
+}
+
+func (f *EditForm) GetDescriptionInput() *tview.InputField {
+	return f.GetFormItemByLabel(descriptionLabel).(*tview.InputField)
+}
+
+func (f *EditForm) GetAmmountInput() *tview.InputField {
+	return f.GetFormItemByLabel(ammountLabel).(*tview.InputField)
+}
+
+func NewEditForm(controller Controller) *EditForm {
+	form := &EditForm{Form: tview.NewForm()}
+	form.SetBorder(true)
+	form.SetTitle("Edit Statement Entry")
+	form.AddInputField(accountLabel, "", 0, nil, nil)
+	form.AddInputField(dateLabel, "", 0, nil, nil)
+	form.AddInputField(descriptionLabel, "", 0, nil, nil)
+	form.AddInputField(ammountLabel, "", 0, nil, nil)
+	form.AddButton("Save", func() {
+		controller.UpdateStatementEntry(
+			form.index,
+			form.GetAccountInput().GetText(),
+			form.GetDateInput().GetText(),
+			form.GetDescriptionInput().GetText(),
+			form.GetAmmountInput().GetText(),
+		)
+	})
+	form.AddButton("Cancel", controller.CancelStatementEntryEdit)
+	form.SetCancelFunc(controller.CancelStatementEntryEdit)
+	return form
+}
@@ -0,0 +1,75 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rivo/tview"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/display/statement"
+	"github.com/vitorqb/addledger/internal/finance"
+	. "github.com/vitorqb/addledger/mocks/display/statement"
+)
+
+func TestEditForm(t *testing.T) {
+	entry := finance.StatementEntry{
+		Account:     "ACC",
+		Date:        time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC),
+		Description: "FOO",
+		Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(-1221, -2)},
+	}
+	enterEvent := tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone)
+	type testcontext struct {
+		controller *MockController
+		form       *EditForm
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, c *testcontext)
+	}
+	var testcases = []testcase{
+		{
+			name: "Fills the form with the entry",
+			run: func(t *testing.T, c *testcontext) {
+				c.form.SetEntry(1, entry)
+				assert.Equal(t, "ACC", c.form.GetAccountInput().GetText())
+				assert.Equal(t, "2023-10-31", c.form.GetDateInput().GetText())
+				assert.Equal(t, "FOO", c.form.GetDescriptionInput().GetText())
+				assert.Equal(t, "EUR -12.21", c.form.GetAmmountInput().GetText())
+			},
+		},
+		{
+			name: "Calls controller on save",
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.EXPECT().UpdateStatementEntry(1, "ACC2", "2023-10-30", "BAR", "12.21")
+				c.form.SetEntry(1, entry)
+				c.form.GetAccountInput().SetText("ACC2")
+				c.form.GetDateInput().SetText("2023-10-30")
+				c.form.GetDescriptionInput().SetText("BAR")
+				c.form.GetAmmountInput().SetText("12.21")
+				c.form.GetButton(0).InputHandler()(enterEvent, func(tview.Primitive) {})
+			},
+		},
+		{
+			name: "Calls controller on cancel",
+			run: func(t *testing.T, c *testcontext) {
+				c.controller.EXPECT().CancelStatementEntryEdit().Times(1)
+				c.form.SetEntry(0, entry)
+				c.form.GetButton(1).InputHandler()(enterEvent, func(tview.Primitive) {})
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := new(testcontext)
+			c.controller = NewMockController(ctrl)
+			c.form = NewEditForm(c.controller)
+			tc.run(t, c)
+		})
+	}
+}
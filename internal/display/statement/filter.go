@@ -0,0 +1,128 @@
+package statement
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/vitorqb/addledger/internal/finance"
+)
+
+// SortKey is a field the statement entries can be sorted by.
+type SortKey string
+
+const (
+	// SortByPosition keeps the entries in the order of the state.
+	SortByPosition    SortKey = ""
+	SortByDate        SortKey = "date"
+	SortByAmmount     SortKey = "ammount"
+	SortByDescription SortKey = "description"
+)
+
+// Sort is the order in which the statement entries are displayed.
+type Sort struct {
+	Key        SortKey
+	Descending bool
+}
+
+// Toggle returns the sort after the user asks to sort by `key`. Sorting by
+// the same key cycles between ascending, descending and the original order.
+func (s Sort) Toggle(key SortKey) Sort {
+	switch {
+	case s.Key != key:
+		return Sort{Key: key}
+	case !s.Descending:
+		return Sort{Key: key, Descending: true}
+	default:
+		return Sort{}
+	}
+}
+
+// String returns a description of the sort for the user.
+func (s Sort) String() string {
+	if s.Key == SortByPosition {
+		return ""
+	}
+	if s.Descending {
+		return "sorted by " + string(s.Key) + " (desc)"
+	}
+	return "sorted by " + string(s.Key) + " (asc)"
+}
+
+func (s Sort) less(a, b finance.StatementEntry) bool {
+	switch s.Key {
+	case SortByDate:
+		return a.Date.Before(b.Date)
+	case SortByAmmount:
+		return a.Ammount.Quantity.LessThan(b.Ammount.Quantity)
+	case SortByDescription:
+		return strings.ToLower(a.Description) < strings.ToLower(b.Description)
+	}
+	return false
+}
+
+// entryMatcher matches statement entries against a term of a filter.
+type entryMatcher func(finance.StatementEntry) bool
+
+// parseFilterTerm parses a term of a filter. `>x` and `<x` match ammounts
+// greater or lower than x, and `x..y` ammounts between x and y (inclusive).
+// Other terms match entries whose description or account contain them.
+func parseFilterTerm(term string) entryMatcher {
+	if strings.HasPrefix(term, ">") {
+		if x, err := decimal.NewFromString(term[1:]); err == nil {
+			return func(e finance.StatementEntry) bool { return e.Ammount.Quantity.GreaterThan(x) }
+		}
+	}
+	if strings.HasPrefix(term, "<") {
+		if x, err := decimal.NewFromString(term[1:]); err == nil {
+			return func(e finance.StatementEntry) bool { return e.Ammount.Quantity.LessThan(x) }
+		}
+	}
+	if bounds := strings.SplitN(term, "..", 2); len(bounds) == 2 {
+		min, minErr := decimal.NewFromString(bounds[0])
+		max, maxErr := decimal.NewFromString(bounds[1])
+		if minErr == nil && maxErr == nil {
+			return func(e finance.StatementEntry) bool {
+				return e.Ammount.Quantity.GreaterThanOrEqual(min) && e.Ammount.Quantity.LessThanOrEqual(max)
+			}
+		}
+	}
+	text := strings.ToLower(term)
+	return func(e finance.StatementEntry) bool {
+		return strings.Contains(strings.ToLower(e.Description), text) ||
+			strings.Contains(strings.ToLower(e.OriginalDescription), text) ||
+			strings.Contains(strings.ToLower(e.Account), text)
+	}
+}
+
+// Rows returns the indexes of the statement entries to display, given a
+// filter and a sort. All (space separated) terms of the filter must match.
+func Rows(entries []finance.StatementEntry, filter string, order Sort) []int {
+	matchers := []entryMatcher{}
+	for _, term := range strings.Fields(filter) {
+		matchers = append(matchers, parseFilterTerm(term))
+	}
+	rows := []int{}
+	for i, entry := range entries {
+		matches := true
+		for _, matcher := range matchers {
+			if !matcher(entry) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			rows = append(rows, i)
+		}
+	}
+	if order.Key != SortByPosition {
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := entries[rows[i]], entries[rows[j]]
+			if order.Descending {
+				return order.less(b, a)
+			}
+			return order.less(a, b)
+		})
+	}
+	return rows
+}
@@ -0,0 +1,71 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/display/statement"
+	"github.com/vitorqb/addledger/internal/finance"
+)
+
+func TestRows(t *testing.T) {
+	entry := func(account, description string, day int, quantity int64) finance.StatementEntry {
+		return finance.StatementEntry{
+			Account:     account,
+			Description: description,
+			Date:        time.Date(2023, 10, day, 0, 0, 0, 0, time.UTC),
+			Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(quantity, 0)},
+		}
+	}
+	entries := []finance.StatementEntry{
+		entry("card", "Amazon Marketplace", 3, -20),
+		entry("checking", "Salary", 1, 1000),
+		entry("card", "amazon prime", 2, -9),
+		entry("checking", "Supermarket", 4, -50),
+	}
+	entries[3].OriginalDescription = "COMPRA TARJ. 5402XXXXXXXX1234 MERCADONA SA"
+	type testcase struct {
+		name     string
+		filter   string
+		sort     Sort
+		expected []int
+	}
+	testcases := []testcase{
+		{name: "no filter", expected: []int{0, 1, 2, 3}},
+		{name: "by description", filter: "AMAZON", expected: []int{0, 2}},
+		{name: "by account", filter: "checking", expected: []int{1, 3}},
+		{name: "by original description", filter: "mercadona", expected: []int{3}},
+		{name: "greater than", filter: ">-10", expected: []int{1, 2}},
+		{name: "lower than", filter: "<-10", expected: []int{0, 3}},
+		{name: "range", filter: "-20..-9", expected: []int{0, 2}},
+		{name: "all terms must match", filter: "card <-10", expected: []int{0}},
+		{name: "invalid range is text", filter: "a..b", expected: []int{}},
+		{name: "sort by date", sort: Sort{Key: SortByDate}, expected: []int{1, 2, 0, 3}},
+		{name: "sort by date desc", sort: Sort{Key: SortByDate, Descending: true}, expected: []int{3, 0, 2, 1}},
+		{name: "sort by ammount", sort: Sort{Key: SortByAmmount}, expected: []int{3, 0, 2, 1}},
+		{name: "sort by description", sort: Sort{Key: SortByDescription}, expected: []int{0, 2, 1, 3}},
+		{name: "filter and sort", filter: "amazon", sort: Sort{Key: SortByDate}, expected: []int{2, 0}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Rows(entries, tc.filter, tc.sort))
+		})
+	}
+}
+
+func TestSortToggle(t *testing.T) {
+	sort := Sort{}
+	assert.Equal(t, "", sort.String())
+	sort = sort.Toggle(SortByDate)
+	assert.Equal(t, Sort{Key: SortByDate}, sort)
+	assert.Equal(t, "sorted by date (asc)", sort.String())
+	sort = sort.Toggle(SortByDate)
+	assert.Equal(t, Sort{Key: SortByDate, Descending: true}, sort)
+	assert.Equal(t, "sorted by date (desc)", sort.String())
+	sort = sort.Toggle(SortByDate)
+	assert.Equal(t, Sort{}, sort)
+	sort = sort.Toggle(SortByDate).Toggle(SortByAmmount)
+	assert.Equal(t, Sort{Key: SortByAmmount}, sort)
+}
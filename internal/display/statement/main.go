@@ -1,6 +1,7 @@
 package statement
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
@@ -11,24 +12,47 @@ import (
 
 //go:generate $MOCKGEN --source=main.go --destination=../../../mocks/display/statement/main_mock.go
 
-const DateFormat = "2006-01-02"
+const DateFormat = finance.StatementDateFormat
 
 type Controller interface {
 	LoadRequest()
 	HideModal()
 	DiscardStatementEntry(index int)
+	DiscardDuplicatedStatementEntries()
+	UnloadStatementSource(index int)
+	UseStatementEntry(index int)
+	EditStatementEntry(index int)
+	UpdateStatementEntry(index int, account, date, description, ammount string)
+	CancelStatementEntryEdit()
 }
 
 type Modal struct {
 	*tview.Flex
 	controller Controller
+	state      *state.State
+	context    *Context
+	actions    []RuneAction
+	table      *Table
+	editForm   *EditForm
+	commandBar tview.Primitive
+	searchBar  *tview.InputField
+	// editing is true while the edit form is displayed instead of the table.
+	editing bool
+	// rows maps the rows of the table to indexes of the statement entries.
+	rows []int
 }
 
 // Contextual information about the current state of the statement display.
 type Context struct {
 	// Tracks the index of the selected statement in the state.GetStatementEntries
-	// slice.
+	// slice, or -1 if no statement is selected.
 	SelectedStatementIndex int
+	// Filter is the query used to filter the entries in the table.
+	Filter string
+	// Sort is the order of the entries in the table.
+	Sort Sort
+	// Searching is true while the user is typing the filter.
+	Searching bool
 }
 
 // RuneAction represents a modal action from a simple rune being presset. The
@@ -62,6 +86,12 @@ func (t *Table) Refresh(entries []finance.StatementEntry) {
 		t.SetCell(i, 2, tview.NewTableCell("\""+e.Description+"\""))
 		t.SetCell(i, 3, tview.NewTableCell(e.Ammount.Commodity))
 		t.SetCell(i, 4, tview.NewTableCell(e.Ammount.Quantity.StringFixed(2)))
+		if e.Source != "" {
+			t.SetCell(i, 5, tview.NewTableCell(filepath.Base(e.Source)).SetTextColor(tcell.ColorGray))
+		}
+		if e.Duplicate {
+			t.SetCell(i, 6, tview.NewTableCell("(duplicate?)").SetTextColor(tcell.ColorYellow))
+		}
 	}
 	t.SetOffset(0, 0)
 }
@@ -77,41 +107,160 @@ func CreateModal(controller Controller, state *state.State, actions []RuneAction
 	context := &Context{}
 
 	table := NewTable()
+	modal := &Modal{
+		Flex:       tview.NewFlex(),
+		controller: controller,
+		state:      state,
+		context:    context,
+		actions:    actions,
+		table:      table,
+		editForm:   NewEditForm(controller),
+		commandBar: NewCommandBar(actions),
+		searchBar:  tview.NewInputField().SetLabel("/"),
+	}
 	table.SetSelectionChangedFunc(func(row, col int) {
-		context.SelectedStatementIndex = row
+		context.SelectedStatementIndex = modal.rowIndex(row)
 	})
-	table.Refresh(state.GetStatementEntries())
-	state.AddOnChangeHook(func() { table.Refresh(state.GetStatementEntries()) })
-
-	modal := &Modal{tview.NewFlex(), controller}
-	modal.SetDirection(tview.FlexRow)
-	modal.AddItem(table, 0, 8, true)
-	modal.AddItem(NewCommandBar(actions), 0, 2, false)
-	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyRune:
-			for _, action := range actions {
-				if event.Rune() == action.Rune {
-					action.Action(controller, context)
-					return nil
-				}
-			}
-		case tcell.KeyEscape, tcell.KeyCtrlQ:
-			modal.controller.HideModal()
-			return nil
+	modal.searchBar.SetChangedFunc(func(text string) {
+		context.Filter = text
+		modal.refreshTable()
+	})
+	modal.searchBar.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			modal.searchBar.SetText("")
 		}
-		return event
+		context.Searching = false
+	})
+	modal.SetDirection(tview.FlexRow)
+	modal.setBody(table)
+	modal.refreshTable()
+	modal.refreshEditing(state)
+	state.AddOnChangeHook(func() {
+		modal.refreshTable()
+		modal.refreshEditing(state)
 	})
 	return modal
 }
 
+// InputHandler handles the modal actions, unless the user is editing an entry
+// or typing a filter. Then focus is moved to or from the search bar if
+// needed.
+func (m *Modal) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if m.editing || m.context.Searching || !m.handleAction(event) {
+			m.Flex.InputHandler()(event, setFocus)
+		}
+		if m.context.Searching != m.searchBar.HasFocus() {
+			setFocus(m)
+		}
+	}
+}
+
+// Focus focus the search bar while the user is typing a filter.
+func (m *Modal) Focus(delegate func(p tview.Primitive)) {
+	if m.context.Searching && !m.editing {
+		delegate(m.searchBar)
+		return
+	}
+	m.Flex.Focus(delegate)
+}
+
+// handleAction runs the modal action for an event, returning true if there
+// was one.
+func (m *Modal) handleAction(event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyRune:
+		for _, action := range m.actions {
+			if event.Rune() == action.Rune {
+				action.Action(m.controller, m.context)
+				m.refreshTable()
+				return true
+			}
+		}
+	case tcell.KeyEscape, tcell.KeyCtrlQ:
+		m.controller.HideModal()
+		return true
+	}
+	return false
+}
+
+// refreshTable displays the statement entries matching the filter, in the
+// selected order.
+func (m *Modal) refreshTable() {
+	entries := m.state.GetStatementEntries()
+	m.rows = Rows(entries, m.context.Filter, m.context.Sort)
+	visible := make([]finance.StatementEntry, len(m.rows))
+	for i, index := range m.rows {
+		visible[i] = entries[index]
+	}
+	m.table.Refresh(visible)
+	m.table.SetTitle(m.context.Sort.String())
+	row, _ := m.table.GetSelection()
+	if row >= len(m.rows) && len(m.rows) > 0 {
+		row = len(m.rows) - 1
+		m.table.Select(row, 0)
+	}
+	m.context.SelectedStatementIndex = m.rowIndex(row)
+}
+
+// rowIndex returns the index of the statement entry displayed in a row.
+func (m *Modal) rowIndex(row int) int {
+	if row < 0 || row >= len(m.rows) {
+		return -1
+	}
+	return m.rows[row]
+}
+
+// refreshEditing displays the edit form instead of the table while a
+// statement entry is being edited.
+func (m *Modal) refreshEditing(state *state.State) {
+	index, editing := state.Display.StatementModal.EditingEntry()
+	entries := state.GetStatementEntries()
+	editing = editing && index < len(entries)
+	if editing == m.editing {
+		return
+	}
+	m.editing = editing
+	if editing {
+		m.editForm.SetEntry(index, entries[index])
+		m.setBody(m.editForm)
+		return
+	}
+	m.setBody(m.table)
+}
+
+// setBody sets the primitive displayed above the command and search bars.
+func (m *Modal) setBody(body tview.Primitive) {
+	m.Clear()
+	m.AddItem(body, 0, 8, true)
+	m.AddItem(m.commandBar, 0, 2, false)
+	m.AddItem(m.searchBar, 1, 0, false)
+}
+
+// IsEditing returns true if the edit form is displayed.
+func (m *Modal) IsEditing() bool { return m.editing }
+
+// GetEditForm returns the form used to edit statement entries.
+func (m *Modal) GetEditForm() *EditForm { return m.editForm }
+
+// GetSearchBar returns the input used to type the filter.
+func (m *Modal) GetSearchBar() *tview.InputField { return m.searchBar }
+
 // Actions available in the modal.
 var defaultActions = []RuneAction{
 	{'l', "Load Statement", func(c Controller, ctx *Context) {
 		c.LoadRequest()
 		c.HideModal()
 	}},
+	{'u', "Use This Entry", func(c Controller, ctx *Context) { c.UseStatementEntry(ctx.SelectedStatementIndex) }},
 	{'d', "Discard Statement Entry", func(c Controller, ctx *Context) { c.DiscardStatementEntry(ctx.SelectedStatementIndex) }},
+	{'D', "Discard Duplicates", func(c Controller, ctx *Context) { c.DiscardDuplicatedStatementEntries() }},
+	{'e', "Edit Statement Entry", func(c Controller, ctx *Context) { c.EditStatementEntry(ctx.SelectedStatementIndex) }},
+	{'x', "Unload Statement", func(c Controller, ctx *Context) { c.UnloadStatementSource(ctx.SelectedStatementIndex) }},
+	{'/', "Search", func(c Controller, ctx *Context) { ctx.Searching = true }},
+	{'1', "Sort by Date", func(c Controller, ctx *Context) { ctx.Sort = ctx.Sort.Toggle(SortByDate) }},
+	{'2', "Sort by Ammount", func(c Controller, ctx *Context) { ctx.Sort = ctx.Sort.Toggle(SortByAmmount) }},
+	{'3', "Sort by Description", func(c Controller, ctx *Context) { ctx.Sort = ctx.Sort.Toggle(SortByDescription) }},
 	{'q', "Quit", func(c Controller, ctx *Context) { c.HideModal() }},
 }
 
@@ -100,6 +100,22 @@ func TestTable(t *testing.T) {
 		assert.Equal(t, strings.Join(exp, "\n"), text)
 	})
 
+	t.Run("marks duplicates", func(t *testing.T) {
+		_, table := setup()
+		entries := []finance.StatementEntry{{Account: "Foo", Duplicate: true}, {Account: "Bar"}}
+		table.Refresh(entries)
+		assert.Equal(t, "(duplicate?)", table.GetCell(0, 6).Text)
+		assert.Equal(t, "", table.GetCell(1, 6).Text)
+	})
+
+	t.Run("shows the statement source", func(t *testing.T) {
+		_, table := setup()
+		entries := []finance.StatementEntry{{Account: "Foo", Source: "/tmp/checking.csv"}, {Account: "Bar"}}
+		table.Refresh(entries)
+		assert.Equal(t, "checking.csv", table.GetCell(0, 5).Text)
+		assert.Equal(t, "", table.GetCell(1, 5).Text)
+	})
+
 	t.Run("clear table on refresh", func(t *testing.T) {
 		_, table := setup()
 		entry1 := finance.StatementEntry{Account: "Foo"}
@@ -189,6 +205,87 @@ func TestNewModal(t *testing.T) {
 		modal.InputHandler()(escapeEventKey, fakeSetFocus)
 	})
 
+	t.Run("displays edit form while editing", func(t *testing.T) {
+		controller, modal, state, teardown := setup(setupOptions{
+			Actions: []RuneAction{{'l', "Foo", func(c Controller, ctx *Context) { c.LoadRequest() }}},
+		})
+		defer teardown()
+		state.SetStatementEntries([]finance.StatementEntry{{Account: "foo"}, {Account: "bar"}})
+		state.Display.StatementModal.SetEditingEntry(1)
+		assert.True(t, modal.IsEditing())
+		assert.Equal(t, modal.GetEditForm(), modal.GetItem(0))
+		assert.Equal(t, "bar", modal.GetEditForm().GetAccountInput().GetText())
+		assert.IsType(t, &CommandBar{}, modal.GetItem(1))
+
+		// Actions are not dispatched while editing
+		modal.InputHandler()(lEventKey, fakeSetFocus)
+
+		state.Display.StatementModal.ClearEditingEntry()
+		assert.False(t, modal.IsEditing())
+		assert.IsType(t, &Table{}, modal.GetItem(0))
+		controller.EXPECT().LoadRequest()
+		modal.InputHandler()(lEventKey, fakeSetFocus)
+	})
+
+	t.Run("maps filtered and sorted rows to statement entries", func(t *testing.T) {
+		var selectedStatementIndex int
+		fakeAction := func(c Controller, ctx *Context) {
+			selectedStatementIndex = ctx.SelectedStatementIndex
+		}
+		_, modal, state, teardown := setup(setupOptions{
+			Actions: []RuneAction{
+				{'l', "Foo", fakeAction},
+				{'s', "Sort", func(c Controller, ctx *Context) { ctx.Sort = ctx.Sort.Toggle(SortByDescription) }},
+			},
+		})
+		defer teardown()
+		state.SetStatementEntries([]finance.StatementEntry{
+			{Description: "foo"},
+			{Description: "bar"},
+			{Description: "baz"},
+		})
+		table := modal.GetItem(0).(*Table)
+
+		modal.GetSearchBar().SetText("ba")
+		assert.Equal(t, 2, table.GetRowCount())
+		table.Select(1, 0)
+		modal.InputHandler()(lEventKey, fakeSetFocus)
+		assert.Equal(t, 2, selectedStatementIndex)
+
+		modal.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone), fakeSetFocus)
+		assert.Equal(t, "\"baz\"", table.GetCell(1, 2).Text)
+		assert.Equal(t, "sorted by description (asc)", table.GetTitle())
+		modal.InputHandler()(lEventKey, fakeSetFocus)
+		assert.Equal(t, 2, selectedStatementIndex)
+
+		modal.GetSearchBar().SetText("nothing")
+		modal.InputHandler()(lEventKey, fakeSetFocus)
+		assert.Equal(t, -1, selectedStatementIndex)
+	})
+
+	t.Run("focus search bar while searching", func(t *testing.T) {
+		_, modal, _, teardown := setup(setupOptions{
+			Actions: []RuneAction{{'/', "Search", func(c Controller, ctx *Context) { ctx.Searching = true }}},
+		})
+		defer teardown()
+		var focused tview.Primitive
+		var setFocus func(p tview.Primitive)
+		setFocus = func(p tview.Primitive) {
+			focused = p
+			p.Focus(setFocus)
+		}
+		modal.InputHandler()(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone), setFocus)
+		assert.Equal(t, modal.GetSearchBar(), focused)
+
+		// Typing goes to the search bar, not to the actions
+		modal.InputHandler()(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone), setFocus)
+		assert.Equal(t, "/", modal.GetSearchBar().GetText())
+
+		modal.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, ' ', tcell.ModNone), setFocus)
+		assert.IsType(t, &Table{}, focused)
+		assert.Equal(t, "/", modal.GetSearchBar().GetText())
+	})
+
 	t.Run("refreshes table with entries", func(t *testing.T) {
 		_, modal, state, teardown := setup(setupOptions{})
 		defer teardown()
@@ -2,6 +2,7 @@ package display
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/rivo/tview"
 	statemod "github.com/vitorqb/addledger/internal/state"
@@ -27,7 +28,7 @@ func (s *StatementDisplay) Refresh() {
 		s.SetText("")
 		return
 	}
-	s.SetText(fmt.Sprintf(
+	text := fmt.Sprintf(
 		"%s | %s | %s | %s %s | [%d]",
 		staEntry.Date.Format("2006/01/02"),
 		staEntry.Description,
@@ -35,5 +36,15 @@ func (s *StatementDisplay) Refresh() {
 		staEntry.Ammount.Commodity,
 		staEntry.Ammount.Quantity.String(),
 		len(s.state.StatementEntries),
-	))
+	)
+	if staEntry.OriginalDescription != "" {
+		text += " | was: " + staEntry.OriginalDescription
+	}
+	if staEntry.Duplicate {
+		text += " | duplicate?"
+	}
+	if staEntry.Source != "" {
+		text += " | " + filepath.Base(staEntry.Source)
+	}
+	s.SetText(text)
 }
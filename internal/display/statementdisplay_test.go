@@ -39,6 +39,51 @@ func TestStatementDisplay(t *testing.T) {
 				assert.Equal(t, "2023/10/31 | FOO | ACC | EUR 12.21 | [1]", c.statementDisplay.GetText(false))
 			},
 		},
+		{
+			name: "Displays whether statement is a duplicate",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					{
+						Account:     "ACC",
+						Date:        time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC),
+						Description: "FOO",
+						Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1, 0)},
+						Duplicate:   true,
+					},
+				})
+				assert.Equal(t, "2023/10/31 | FOO | ACC | EUR 1 | [1] | duplicate?", c.statementDisplay.GetText(false))
+			},
+		},
+		{
+			name: "Displays the original description",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					{
+						Account:             "ACC",
+						Date:                time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC),
+						Description:         "Mercadona",
+						OriginalDescription: "COMPRA MERCADONA SA",
+						Ammount:             finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1, 0)},
+					},
+				})
+				assert.Equal(t, "2023/10/31 | Mercadona | ACC | EUR 1 | [1] | was: COMPRA MERCADONA SA", c.statementDisplay.GetText(false))
+			},
+		},
+		{
+			name: "Displays the statement source",
+			run: func(c *testcontext, t *testing.T) {
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					{
+						Account:     "ACC",
+						Date:        time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC),
+						Description: "FOO",
+						Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1, 0)},
+						Source:      "/home/user/card.csv",
+					},
+				})
+				assert.Equal(t, "2023/10/31 | FOO | ACC | EUR 1 | [1] | card.csv", c.statementDisplay.GetText(false))
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -31,6 +31,36 @@ func (a Ammount) Round(i int32) Ammount {
 	return Ammount{a.Commodity, a.Quantity.Round(i)}
 }
 
+// SplitPrecision is the number of decimal places of the shares of Split.
+const SplitPrecision = 2
+
+// Split splits an ammount evenly in `n` shares with SplitPrecision decimal
+// places. The remaining cents go to the first shares, so that the shares
+// always sum to the ammount.
+func (a Ammount) Split(n int) []Ammount {
+	if n < 1 {
+		return []Ammount{}
+	}
+	count := decimal.New(int64(n), 0)
+	share := a.Quantity.Div(count).Truncate(SplitPrecision)
+	remainder := a.Quantity.Sub(share.Mul(count))
+	unit := decimal.New(1, -SplitPrecision)
+	if a.Quantity.IsNegative() {
+		unit = unit.Neg()
+	}
+	shares := make([]Ammount, n)
+	for i := range shares {
+		shares[i] = Ammount{a.Commodity, share}
+		if remainder.Abs().GreaterThanOrEqual(unit.Abs()) {
+			shares[i].Quantity = shares[i].Quantity.Add(unit)
+			remainder = remainder.Sub(unit)
+		}
+	}
+	// Anything below the precision goes to the first share
+	shares[0].Quantity = shares[0].Quantity.Add(remainder)
+	return shares
+}
+
 // A balance is a list of Ammounts, where each Ammount has a different
 // commodity. It represents the balance of a transaction.
 type Balance struct {
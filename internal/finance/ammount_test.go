@@ -86,6 +86,40 @@ func TestNewBalance(t *testing.T) {
 
 }
 
+func TestAmmountSplit(t *testing.T) {
+	eur := func(x string) Ammount {
+		return Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+	}
+	type testcase struct {
+		name     string
+		ammount  Ammount
+		n        int
+		expected []string
+	}
+	testcases := []testcase{
+		{name: "Exact", ammount: eur("90"), n: 3, expected: []string{"30", "30", "30"}},
+		{name: "Remaining cents", ammount: eur("100"), n: 3, expected: []string{"33.34", "33.33", "33.33"}},
+		{name: "Negative", ammount: eur("-0.05"), n: 3, expected: []string{"-0.02", "-0.02", "-0.01"}},
+		{name: "Below precision", ammount: eur("1.005"), n: 2, expected: []string{"0.505", "0.50"}},
+		{name: "Single", ammount: eur("12.2"), n: 1, expected: []string{"12.2"}},
+		{name: "None", ammount: eur("12.2"), n: 0, expected: []string{}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			shares := tc.ammount.Split(tc.n)
+			assert.Len(t, shares, len(tc.expected))
+			total := decimal.Zero
+			for i, share := range shares {
+				assert.True(t, eur(tc.expected[i]).Equal(share), share.Quantity.String())
+				total = total.Add(share.Quantity)
+			}
+			if tc.n > 0 {
+				assert.True(t, tc.ammount.Quantity.Equal(total))
+			}
+		})
+	}
+}
+
 func TestBalance(t *testing.T) {
 	t.Run("SingleCommodity", func(t *testing.T) {
 		t.Run("Empty", func(t *testing.T) {
@@ -2,14 +2,50 @@ package finance
 
 import "time"
 
+// StatementDateFormat is the format in which statement entry dates are shown
+// to and edited by the user.
+const StatementDateFormat = "2006-01-02"
+
 // StatementEntry represents a single entry in a bank/credit card statement.
 type StatementEntry struct {
 	// Account is the account of the entry.
 	Account string
 	// Date is the date of the entry.
 	Date time.Time
+	// ValueDate is the date in which the entry was effective, if known.
+	ValueDate time.Time
 	// Description is a description of the entry.
 	Description string
+	// OriginalDescription is the description read from the statement, if it
+	// was rewritten by description rules.
+	OriginalDescription string
 	// Amount is the amount of the entry.
 	Ammount Ammount
+	// Splits are the parts in which the entry is split, if any (e.g. from QIF
+	// split lines). They can be used to pre-fill the entry counter postings.
+	Splits []StatementEntrySplit
+	// CounterAccount is a suggested account for the counter posting of the
+	// entry, if known (e.g. from hledger rules).
+	CounterAccount string
+	// Duplicate is true if the entry is probably already in the journal.
+	Duplicate bool
+	// Source identifies the statement the entry was loaded from.
+	Source string
+	// Fields are extra named fields of the entry, read from the statement
+	// (e.g. a bank reference or the running balance).
+	Fields map[string]string
+	// TagFields are the names of the fields used as tags of the transaction.
+	TagFields []string
+	// Comment is a comment for the transaction, if any.
+	Comment string
+}
+
+// StatementEntrySplit represents a part of a StatementEntry.
+type StatementEntrySplit struct {
+	// Account is the account (category) of the split.
+	Account string
+	// Description is a description (memo) of the split.
+	Description string
+	// Ammount is the ammount of the split.
+	Ammount Ammount
 }
@@ -2,6 +2,9 @@
 package injector
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/vitorqb/addledger/internal/accountguesser"
 	"github.com/vitorqb/addledger/internal/ammountguesser"
 	configmod "github.com/vitorqb/addledger/internal/config"
@@ -9,6 +12,7 @@ import (
 	"github.com/vitorqb/addledger/internal/metaloader"
 	"github.com/vitorqb/addledger/internal/printer"
 	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/statementprogress"
 	"github.com/vitorqb/addledger/internal/statementreader"
 	"github.com/vitorqb/addledger/internal/stringmatcher"
 	"github.com/vitorqb/addledger/internal/transactionmatcher"
@@ -22,12 +26,18 @@ func HledgerClient(config *configmod.Config) hledger.IClient {
 }
 
 // AmmountGuesser instantiates a new guesser for ammount.
-func AmmountGuesser() ammountguesser.IAmmountGuesser {
-	return ammountguesser.New()
+func AmmountGuesser(config *configmod.Config) ammountguesser.IAmmountGuesser {
+	return ammountguesser.New(config.DefaultCommodity)
 }
 
-func DateGuesser() (dateguesser.IDateGuesser, error) {
-	return dateguesser.New()
+// DateGuesser instantiates a new guesser for dates, with the configured base
+// date strategy.
+func DateGuesser(config *configmod.Config) (dateguesser.IDateGuesser, error) {
+	base, err := dateguesser.ParseBaseStrategy(config.DateGuessBase)
+	if err != nil {
+		return nil, err
+	}
+	return dateguesser.New(base)
 }
 
 func State(config configmod.Config) (*statemod.State, error) {
@@ -46,6 +56,28 @@ func DescriptionMatchAccountGuesser(state *statemod.State) (*accountguesser.Matc
 	return accountguesser.NewMatchedTransactionsAccountGuesser()
 }
 
+// CoOccurrenceAccountGuesser instantiates a new CoOccurrenceAccountGuesser
+// trained on the journal, and keeps it in sync with the journal.
+func CoOccurrenceAccountGuesser(state *statemod.State) (*accountguesser.CoOccurrenceAccountGuesser, error) {
+	guesser, err := accountguesser.NewCoOccurrenceAccountGuesser(state.JournalMetadata.Transactions())
+	if err != nil {
+		return nil, err
+	}
+	state.JournalMetadata.AddOnChangeHook(func() { guesser.Sync(state.JournalMetadata.Transactions()) })
+	return guesser, nil
+}
+
+// NaiveBayesAccountGuesser instantiates a new NaiveBayesAccountGuesser
+// trained on the journal, and keeps it in sync with the journal.
+func NaiveBayesAccountGuesser(state *statemod.State) (*accountguesser.NaiveBayesAccountGuesser, error) {
+	guesser, err := accountguesser.NewNaiveBayesAccountGuesser(state.JournalMetadata.Transactions())
+	if err != nil {
+		return nil, err
+	}
+	state.JournalMetadata.AddOnChangeHook(func() { guesser.Sync(state.JournalMetadata.Transactions()) })
+	return guesser, nil
+}
+
 func LastTransactionAccountGuesser(state *statemod.State) (*accountguesser.LastTransactionAccountGuesser, error) {
 	return accountguesser.NewLastTransactionAccountGuesser()
 }
@@ -54,7 +86,20 @@ func StatementAccountGuesser(state *statemod.State) (accountguesser.AccountGuess
 	return accountguesser.NewStatementAccountGuesser()
 }
 
-func AccountGuesser(state *statemod.State) (accountguesser.AccountGuesser, error) {
+// RulesAccountGuesser loads the account guesser with the user rules.
+func RulesAccountGuesser(config configmod.AccountGuesserConfig) (*accountguesser.RulesAccountGuesser, error) {
+	rulesFile := config.RulesFile
+	if rulesFile == "" {
+		rulesFile = filepath.Join(os.Getenv("HOME"), ".config/addledger/account-rules.json")
+	}
+	rules, err := accountguesser.LoadRules(rulesFile)
+	if err != nil {
+		return nil, err
+	}
+	return accountguesser.NewRulesAccountGuesser(rules)
+}
+
+func AccountGuesser(state *statemod.State, config configmod.AccountGuesserConfig) (accountguesser.AccountGuesser, error) {
 	// Returns a composite of all account guessers
 	statementAccountGuesser, err := StatementAccountGuesser(state)
 	if err != nil {
@@ -64,15 +109,39 @@ func AccountGuesser(state *statemod.State) (accountguesser.AccountGuesser, error
 	if err != nil {
 		return nil, err
 	}
+	naiveBayesAccountGuesser, err := NaiveBayesAccountGuesser(state)
+	if err != nil {
+		return nil, err
+	}
+	coOccurrenceAccountGuesser, err := CoOccurrenceAccountGuesser(state)
+	if err != nil {
+		return nil, err
+	}
 	lastTransactionAccountGuesser, err := LastTransactionAccountGuesser(state)
 	if err != nil {
 		return nil, err
 	}
-	return accountguesser.NewCompositeAccountGuesser(
+	rulesAccountGuesser, err := RulesAccountGuesser(config)
+	if err != nil {
+		return nil, err
+	}
+	guessers := []accountguesser.AccountGuesser{
 		statementAccountGuesser,
 		descriptionMatchAccountGuesser,
+		naiveBayesAccountGuesser,
+		// Ignores the description, so it's only a fallback.
+		coOccurrenceAccountGuesser,
 		lastTransactionAccountGuesser,
-	)
+	}
+	priority := config.RulesPriority
+	if priority < 0 {
+		priority = 0
+	}
+	if priority > len(guessers) {
+		priority = len(guessers)
+	}
+	guessers = append(guessers[:priority], append([]accountguesser.AccountGuesser{rulesAccountGuesser}, guessers[priority:]...)...)
+	return accountguesser.NewCompositeAccountGuesser(guessers...)
 }
 
 func Printer(config configmod.PrinterConfig) (printer.IPrinter, error) {
@@ -83,9 +152,23 @@ func StatementReader() statementreader.IStatementReader {
 	return statementreader.NewStatementReader()
 }
 
+// StringMatcher instantiates a new string matcher.
+func StringMatcher() (stringmatcher.IStringMatcher, error) {
+	// We could configure the stringmatcher here if we ever want to make it configurable.
+	return stringmatcher.New(&stringmatcher.Options{})
+}
+
+// StatementProgressStore instantiates a new store for statement progress.
+func StatementProgressStore() (statementprogress.IStore, error) {
+	dir, err := statementprogress.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return statementprogress.New(dir), nil
+}
+
 func TransactionMatcher() (transactionmatcher.ITransactionMatcher, error) {
-	// We could inject a stringmatcher here if we ever want to make it configurable.
-	stringMatcher, err := stringmatcher.New(&stringmatcher.Options{})
+	stringMatcher, err := StringMatcher()
 	if err != nil {
 		return nil, err
 	}
@@ -2,14 +2,20 @@ package injector_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/vitorqb/addledger/internal/accountguesser"
 	"github.com/vitorqb/addledger/internal/config"
+	"github.com/vitorqb/addledger/internal/dateguesser"
+	"github.com/vitorqb/addledger/internal/finance"
 	"github.com/vitorqb/addledger/internal/injector"
 	. "github.com/vitorqb/addledger/internal/injector"
 	"github.com/vitorqb/addledger/internal/journal"
+	statemod "github.com/vitorqb/addledger/internal/state"
 	"github.com/vitorqb/addledger/internal/testutils"
 	hledger_mock "github.com/vitorqb/addledger/mocks/hledger"
 )
@@ -62,3 +68,100 @@ func TestTransactionMatcher(t *testing.T) {
 	matchedTransactions := matcher.Match()
 	assert.Equal(t, expectedMatchedTransactions, matchedTransactions)
 }
+
+func TestAccountGuesser(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "account-rules.json")
+	err := os.WriteFile(rulesFile, []byte(`[{"statementDescription": "uber", "account": "expenses:taxi"}]`), 0644)
+	assert.Nil(t, err)
+	inputs := accountguesser.Inputs{
+		StatementEntry: finance.StatementEntry{Account: "assets:bank", Description: "UBER"},
+	}
+
+	t.Run("Rules after statement", func(t *testing.T) {
+		guesser, err := AccountGuesser(statemod.InitialState(), config.AccountGuesserConfig{RulesFile: rulesFile, RulesPriority: 1})
+		assert.Nil(t, err)
+		account, success := guesser.Guess(inputs)
+		assert.True(t, success)
+		assert.Equal(t, journal.Account("assets:bank"), account)
+	})
+
+	t.Run("Rules first", func(t *testing.T) {
+		guesser, err := AccountGuesser(statemod.InitialState(), config.AccountGuesserConfig{RulesFile: rulesFile, RulesPriority: 0})
+		assert.Nil(t, err)
+		account, success := guesser.Guess(inputs)
+		assert.True(t, success)
+		assert.Equal(t, journal.Account("expenses:taxi"), account)
+	})
+
+	t.Run("Chain order", func(t *testing.T) {
+		guesser, err := AccountGuesser(statemod.InitialState(), config.AccountGuesserConfig{RulesFile: rulesFile, RulesPriority: 1})
+		assert.Nil(t, err)
+		composite, ok := guesser.(*accountguesser.CompositeAccountGuesser)
+		assert.True(t, ok)
+		guessers := composite.Guessers()
+		assert.Len(t, guessers, 6)
+		assert.IsType(t, &accountguesser.StatementAccountGuesser{}, guessers[0])
+		assert.IsType(t, &accountguesser.RulesAccountGuesser{}, guessers[1])
+		assert.IsType(t, &accountguesser.MatchedTransactionsGuesser{}, guessers[2])
+		assert.IsType(t, &accountguesser.NaiveBayesAccountGuesser{}, guessers[3])
+		assert.IsType(t, &accountguesser.CoOccurrenceAccountGuesser{}, guessers[4])
+		assert.IsType(t, &accountguesser.LastTransactionAccountGuesser{}, guessers[5])
+	})
+
+	t.Run("Co-occurrence does not shadow the naive Bayes guesser", func(t *testing.T) {
+		state := statemod.InitialState()
+		state.JournalMetadata.SetTransactions([]journal.Transaction{
+			{Description: "Gym", Posting: []journal.Posting{{Account: "assets:bank"}, {Account: "expenses:gym"}}},
+			{Description: "Mercadona", Posting: []journal.Posting{{Account: "assets:bank"}, {Account: "expenses:groceries"}}},
+			{Description: "Mercadona", Posting: []journal.Posting{{Account: "assets:bank"}, {Account: "expenses:groceries"}}},
+		})
+		guesser, err := AccountGuesser(state, config.AccountGuesserConfig{RulesFile: rulesFile, RulesPriority: 1})
+		assert.Nil(t, err)
+		account, success := guesser.Guess(accountguesser.Inputs{
+			Description:   "Gym",
+			PostingInputs: []journal.Posting{{Account: "assets:bank"}},
+		})
+		assert.True(t, success)
+		assert.Equal(t, journal.Account("expenses:gym"), account)
+	})
+
+	t.Run("Invalid rules file", func(t *testing.T) {
+		invalidFile := filepath.Join(t.TempDir(), "account-rules.json")
+		err := os.WriteFile(invalidFile, []byte(`{`), 0644)
+		assert.Nil(t, err)
+		_, err = AccountGuesser(statemod.InitialState(), config.AccountGuesserConfig{RulesFile: invalidFile})
+		assert.ErrorContains(t, err, "failed to unmarshal account rules")
+	})
+}
+
+func TestNaiveBayesAccountGuesser(t *testing.T) {
+	transaction := func(description, account string) journal.Transaction {
+		return journal.Transaction{
+			Description: description,
+			Posting:     []journal.Posting{{Account: "assets:bank"}, {Account: account}},
+		}
+	}
+	inputs := accountguesser.Inputs{Description: "Gym", PostingInputs: []journal.Posting{{Account: "assets:bank"}}}
+	state := statemod.InitialState()
+	state.JournalMetadata.SetTransactions([]journal.Transaction{transaction("Mercadona", "expenses:groceries")})
+
+	// Trained on the journal when created
+	guesser, err := NaiveBayesAccountGuesser(state)
+	assert.Nil(t, err)
+	account, _ := guesser.Guess(inputs)
+	assert.Equal(t, journal.Account("expenses:groceries"), account)
+
+	// Learns the transactions added to the journal
+	state.JournalMetadata.AppendTransaction(transaction("Gym", "expenses:gym"))
+	account, _ = guesser.Guess(inputs)
+	assert.Equal(t, journal.Account("expenses:gym"), account)
+}
+
+func TestDateGuesser(t *testing.T) {
+	guesser, err := DateGuesser(&config.Config{DateGuessBase: "last"})
+	assert.Nil(t, err)
+	assert.Equal(t, dateguesser.LastTransactionBase, guesser.(*dateguesser.DateGuesser).Base)
+
+	_, err = DateGuesser(&config.Config{DateGuessBase: "foo"})
+	assert.ErrorContains(t, err, "invalid date guess base: foo")
+}
@@ -3,6 +3,7 @@ package journal
 import (
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/vitorqb/addledger/internal/finance"
 )
 
@@ -38,3 +39,88 @@ func PostingsBalance(postings []Posting) finance.Balance {
 	}
 	return finance.NewBalance(ammounts)
 }
+
+// StatementEntryTags returns the tags for a transaction from a statement
+// entry: its fields listed in `TagFields`, skipping empty ones.
+func StatementEntryTags(entry finance.StatementEntry) []Tag {
+	tags := []Tag{}
+	for _, name := range entry.TagFields {
+		if value := entry.Fields[name]; value != "" {
+			tags = append(tags, Tag{Name: name, Value: value})
+		}
+	}
+	return tags
+}
+
+// ScalePrecision is the number of decimal places of the ammounts scaled by
+// ScalePostings.
+const ScalePrecision = 2
+
+// ScalePostings returns the postings scaled so that the posting for the
+// statement `account` has `ammount` (the inverted statement entry ammount, like
+// the guessed ammounts). If no posting is for `account`, they are scaled by the
+// size of the ammount relative to the first posting, keeping their signs.
+// Only the postings with the same commodity as the reference posting are
+// scaled, so that they still balance, and nothing is scaled if the ammount
+// has another commodity. If they balanced, rounding differences go to the
+// last scaled posting.
+func ScalePostings(postings []Posting, account string, ammount finance.Ammount) []Posting {
+	scaled := append([]Posting{}, postings...)
+	if len(scaled) == 0 {
+		return scaled
+	}
+	reference, found := 0, false
+	for i, posting := range scaled {
+		if account != "" && posting.Account == account {
+			reference, found = i, true
+			break
+		}
+	}
+	referenceAmmount := scaled[reference].Ammount
+	if referenceAmmount.Quantity.IsZero() || ammount.Quantity.IsZero() {
+		return scaled
+	}
+	commodity := referenceAmmount.Commodity
+	if ammount.Commodity != "" && ammount.Commodity != commodity {
+		// We don't know the exchange rate
+		return scaled
+	}
+	quantity := ammount.Quantity
+	if !found {
+		// Without the statement account we don't know the sign, so only
+		// the size of the ammount is used
+		quantity = quantity.Abs()
+		if referenceAmmount.Quantity.IsNegative() {
+			quantity = quantity.Neg()
+		}
+	}
+	balanced := commodityBalance(scaled, commodity).IsZero()
+	factor := quantity.Div(referenceAmmount.Quantity)
+	last := -1
+	for i, posting := range scaled {
+		if posting.Ammount.Commodity != commodity {
+			continue
+		}
+		scaled[i].Ammount = posting.Ammount.Mul(factor).Round(ScalePrecision)
+		if i != reference {
+			last = i
+		}
+	}
+	scaled[reference].Ammount.Quantity = quantity
+	if balanced && last != -1 {
+		residual := commodityBalance(scaled, commodity)
+		scaled[last].Ammount.Quantity = scaled[last].Ammount.Quantity.Sub(residual)
+	}
+	return scaled
+}
+
+// commodityBalance returns the sum of the postings with a commodity.
+func commodityBalance(postings []Posting, commodity string) decimal.Decimal {
+	sum := decimal.Zero
+	for _, posting := range postings {
+		if posting.Ammount.Commodity == commodity {
+			sum = sum.Add(posting.Ammount.Quantity)
+		}
+	}
+	return sum
+}
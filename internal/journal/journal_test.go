@@ -0,0 +1,108 @@
+package journal_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/vitorqb/addledger/internal/finance"
+	. "github.com/vitorqb/addledger/internal/journal"
+)
+
+func TestScalePostings(t *testing.T) {
+	eur := func(x string) finance.Ammount {
+		return finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+	}
+	usd := func(x string) finance.Ammount {
+		return finance.Ammount{Commodity: "USD", Quantity: decimal.RequireFromString(x)}
+	}
+	type test struct {
+		name     string
+		postings []Posting
+		account  string
+		ammount  finance.Ammount
+		expected []Posting
+	}
+	tests := []test{
+		{
+			name:     "Empty",
+			postings: []Posting{},
+			account:  "bank",
+			ammount:  eur("-10"),
+			expected: []Posting{},
+		},
+		{
+			name:     "Scales to the posting of the account",
+			postings: []Posting{{"food", eur("15")}, {"bank", eur("-15")}},
+			account:  "bank",
+			ammount:  eur("-30"),
+			expected: []Posting{{"food", eur("30")}, {"bank", eur("-30")}},
+		},
+		{
+			name:     "Scales to the posting of the account when it is not the first",
+			postings: []Posting{{"food", eur("15")}, {"bank", eur("-15")}},
+			account:  "bank",
+			ammount:  eur("20"),
+			expected: []Posting{{"food", eur("-20")}, {"bank", eur("20")}},
+		},
+		{
+			name:     "Keeps the signs if no posting for the account",
+			postings: []Posting{{"food", eur("15")}, {"bank", eur("-15")}},
+			account:  "other",
+			ammount:  eur("-30"),
+			expected: []Posting{{"food", eur("30")}, {"bank", eur("-30")}},
+		},
+		{
+			name:     "Keeps the signs if no account",
+			postings: []Posting{{"food", eur("15")}, {"bank", eur("-15")}},
+			account:  "",
+			ammount:  eur("-30"),
+			expected: []Posting{{"food", eur("30")}, {"bank", eur("-30")}},
+		},
+		{
+			name:     "Rounding differences go to the last posting",
+			postings: []Posting{{"bank", eur("-30")}, {"food", eur("10")}, {"drinks", eur("10")}, {"tips", eur("10")}},
+			account:  "bank",
+			ammount:  eur("-10"),
+			expected: []Posting{{"bank", eur("-10")}, {"food", eur("3.33")}, {"drinks", eur("3.33")}, {"tips", eur("3.34")}},
+		},
+		{
+			name:     "Does not scale to an ammount with another commodity",
+			postings: []Posting{{"bank", eur("-10")}, {"food", eur("10")}, {"broker", usd("5")}, {"cash", usd("-5")}},
+			account:  "bank",
+			ammount:  usd("-20"),
+			expected: []Posting{{"bank", eur("-10")}, {"food", eur("10")}, {"broker", usd("5")}, {"cash", usd("-5")}},
+		},
+		{
+			name:     "Keeps the commodity if the ammount has none",
+			postings: []Posting{{"bank", eur("-15")}, {"food", eur("15")}},
+			account:  "bank",
+			ammount:  finance.Ammount{Quantity: decimal.New(-20, 0)},
+			expected: []Posting{{"bank", eur("-20")}, {"food", eur("20")}},
+		},
+		{
+			name:     "Does not scale other commodities",
+			postings: []Posting{{"bank", eur("-10")}, {"food", eur("10")}, {"broker", usd("5")}, {"cash", usd("-5")}},
+			account:  "bank",
+			ammount:  eur("-20"),
+			expected: []Posting{{"bank", eur("-20")}, {"food", eur("20")}, {"broker", usd("5")}, {"cash", usd("-5")}},
+		},
+		{
+			name:     "Does not scale a zero ammount",
+			postings: []Posting{{"bank", eur("-10")}, {"food", eur("10")}},
+			account:  "bank",
+			ammount:  eur("0"),
+			expected: []Posting{{"bank", eur("-10")}, {"food", eur("10")}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scaled := ScalePostings(tc.postings, tc.account, tc.ammount)
+			assert.Equal(t, len(tc.expected), len(scaled))
+			for i := range tc.expected {
+				assert.Equal(t, tc.expected[i].Account, scaled[i].Account)
+				assert.True(t, tc.expected[i].Ammount.Equal(scaled[i].Ammount), "%v != %v", tc.expected[i].Ammount, scaled[i].Ammount)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 type IMetaLoader interface {
 	LoadTransactions() error
 	LoadAccounts() error
+	LoadDefaultCommodity() error
 }
 
 // MetaLoader implements iMetaLoader
@@ -41,6 +42,16 @@ func (ml *MetaLoader) LoadTransactions() error {
 	return nil
 }
 
+// LoadDefaultCommodity implements IMetaLoader.
+func (ml *MetaLoader) LoadDefaultCommodity() error {
+	commodity, err := ml.hledgerClient.DefaultCommodity()
+	if err != nil {
+		return err
+	}
+	ml.state.JournalMetadata.SetDefaultCommodity(commodity)
+	return nil
+}
+
 // New returns a new instance of MetaLoader
 func New(state *state.State, hledgerClient hledger.IClient) (*MetaLoader, error) {
 	return &MetaLoader{state, hledgerClient}, nil
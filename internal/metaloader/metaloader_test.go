@@ -46,13 +46,17 @@ func TestMetaLoader(t *testing.T) {
 	hledgerClient := hledger_mocks.NewMockIClient(ctrl)
 	hledgerClient.EXPECT().Accounts().Return(accounts, nil)
 	hledgerClient.EXPECT().Transactions().Return(transactions, nil)
+	hledgerClient.EXPECT().DefaultCommodity().Return("BRL", nil)
 	metaLoader, err := New(state, hledgerClient)
 	assert.Nil(t, err)
 	err = metaLoader.LoadTransactions()
 	assert.Nil(t, err)
 	err = metaLoader.LoadAccounts()
 	assert.Nil(t, err)
+	err = metaLoader.LoadDefaultCommodity()
+	assert.Nil(t, err)
 	assert.Equal(t, state.JournalMetadata.Accounts(), accounts)
+	assert.Equal(t, "BRL", state.JournalMetadata.DefaultCommodity())
 	assert.Equal(t, state.JournalMetadata.Transactions(), transactions)
 	assert.Equal(t, state.JournalMetadata.Tags(), transactions[0].Tags)
 }
@@ -0,0 +1,265 @@
+// statementimporter imports a statement without user interaction, writing
+// the transactions the guessers are confident about.
+package statementimporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/vitorqb/addledger/internal/accountguesser"
+	"github.com/vitorqb/addledger/internal/ammountguesser"
+	"github.com/vitorqb/addledger/internal/dateguesser"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+	"github.com/vitorqb/addledger/internal/printer"
+	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/statementprogress"
+	"github.com/vitorqb/addledger/internal/transactionmatcher"
+)
+
+//go:generate $MOCKGEN --source=statementimporter.go --destination=../../../mocks/statementimporter/statementimporter_mock.go
+
+// UnknownAccount is used to balance the transactions written for review when
+// the guessers can't find the missing postings.
+const UnknownAccount = "unknown"
+
+// StatementLoader loads a statement into the app state.
+type StatementLoader interface {
+	LoadFromFiles(statementFile, presetFile, format string) error
+}
+
+// Guessers are the engines used to guess each transaction.
+type Guessers struct {
+	Date               dateguesser.IDateGuesser
+	Account            accountguesser.AccountGuesser
+	Ammount            ammountguesser.IAmmountGuesser
+	TransactionMatcher transactionmatcher.ITransactionMatcher
+}
+
+// Options configure an import.
+type Options struct {
+	// Threshold is the minimum confidence (between 0 and 1) for a
+	// transaction to be written to the output.
+	Threshold float64
+	// Output is where the imported transactions are written.
+	Output io.Writer
+	// Review, if not nil, is where the transactions below the threshold are
+	// written for review. Otherwise the entries are left in a resumable
+	// queue for the TUI.
+	Review io.Writer
+}
+
+// Result is the outcome of an import.
+type Result struct {
+	// Imported are the transactions written to the output.
+	Imported []journal.Transaction
+	// Pending are the statement entries below the threshold.
+	Pending []finance.StatementEntry
+}
+
+// Service imports statements.
+type Service struct {
+	state         *statemod.State
+	loader        StatementLoader
+	progressStore statementprogress.IStore
+	guessers      Guessers
+	printer       printer.IPrinter
+}
+
+// Import loads a statement and guesses a transaction for each of its
+// entries. The transactions with a confidence above the threshold are
+// written to the output. If the statement was partially imported before,
+// only the remaining entries are imported.
+func (s *Service) Import(statementFile, presetFile, format string, options Options) (Result, error) {
+	if err := s.loader.LoadFromFiles(statementFile, presetFile, format); err != nil {
+		return Result{}, fmt.Errorf("failed to load statement: %w", err)
+	}
+	s.state.ResumeStatementProgress()
+	sources := s.state.StatementSources()
+	if len(sources) == 0 {
+		return Result{}, nil
+	}
+	source := sources[len(sources)-1]
+	history := s.state.JournalMetadata.Transactions()
+
+	result := Result{}
+	review := []journal.Transaction{}
+	for _, entry := range s.state.StatementEntries {
+		transaction, confidence := s.guess(entry, history)
+		if entry.Duplicate || confidence < options.Threshold {
+			result.Pending = append(result.Pending, entry)
+			review = append(review, reviewTransaction(transaction, entry, confidence))
+			continue
+		}
+		if err := s.printer.Print(options.Output, transaction); err != nil {
+			return result, fmt.Errorf("failed to write transaction: %w", err)
+		}
+		result.Imported = append(result.Imported, transaction)
+		history = append(history, transaction)
+	}
+
+	remaining := result.Pending
+	if options.Review != nil {
+		for _, transaction := range review {
+			if err := s.printer.Print(options.Review, transaction); err != nil {
+				return result, fmt.Errorf("failed to write transaction for review: %w", err)
+			}
+		}
+		remaining = []finance.StatementEntry{}
+	}
+	progress := statemod.StatementProgress{
+		Remaining: remaining,
+		Discarded: s.state.StatementProgress(source.Name).Discarded,
+	}
+	if err := s.progressStore.Save(source.Key, progress); err != nil {
+		return result, fmt.Errorf("failed to save statement progress: %w", err)
+	}
+	return result, nil
+}
+
+// guess guesses the transaction for a statement entry, entering postings
+// like the user would do in the TUI until they are balanced. The confidence
+// is the lowest confidence on the accounts of the postings.
+func (s *Service) guess(entry finance.StatementEntry, history []journal.Transaction) (journal.Transaction, float64) {
+	transaction := journal.Transaction{
+		Description: entry.Description,
+		Comment:     entry.Comment,
+		Tags:        journal.StatementEntryTags(entry),
+	}
+	// Statement entries are always dated by their own date, if they have one
+	transaction.Date = entry.Date
+	if transaction.Date.IsZero() {
+		transaction.Date, _ = s.guessers.Date.Guess(dateguesser.Inputs{StatementEntry: entry})
+	}
+	if entry.Ammount.Quantity.IsZero() {
+		return transaction, 0
+	}
+
+	s.guessers.TransactionMatcher.SetTransactionHistory(history)
+	s.guessers.TransactionMatcher.SetDescriptionInput(entry.Description)
+	matches := s.guessers.TransactionMatcher.Match()
+
+	confidence := 1.0
+	postingsData := []*statemod.PostingData{}
+	// A posting for the statement account, one for the counter account and
+	// one for each split.
+	maxPostings := 2 + len(entry.Splits)
+	for len(transaction.Posting) < maxPostings {
+		account, found := s.guessers.Account.Guess(accountguesser.Inputs{
+			MatchingTransactions: matches,
+			PostingInputs:        transaction.Posting,
+			Description:          entry.Description,
+			TransactionHistory:   history,
+			StatementEntry:       entry,
+		})
+		if !found || account == "" {
+			return transaction, 0
+		}
+		accountCommodity, _ := s.state.JournalMetadata.AccountCommodity(account)
+		ammount, found := s.guessers.Ammount.Guess(ammountguesser.Inputs{
+			PostingsData:            postingsData,
+			StatementEntry:          entry,
+			MatchingTransactions:    matches,
+			JournalDefaultCommodity: s.state.JournalMetadata.DefaultCommodity(),
+			AccountCommodity:        accountCommodity,
+		})
+		if !found {
+			return transaction, 0
+		}
+		posting := journal.Posting{Account: string(account), Ammount: ammount}
+		confidence = min(confidence, accountConfidence(entry, matches, len(transaction.Posting), posting.Account))
+		transaction.Posting = append(transaction.Posting, posting)
+		postingsData = append(postingsData, newPostingData(posting))
+		if journal.PostingsBalance(transaction.Posting).IsZero() {
+			return transaction, confidence
+		}
+	}
+	return transaction, 0
+}
+
+// accountConfidence returns how confident we are on the account of the
+// posting with index `i`. Accounts from the statement (including the ones
+// set by rules) are certain. Others are as good as the share of the
+// matching transactions that use the same account.
+func accountConfidence(entry finance.StatementEntry, matches []journal.Transaction, i int, account string) float64 {
+	if account == entry.Account || account == entry.CounterAccount {
+		return 1
+	}
+	for _, split := range entry.Splits {
+		if account == split.Account {
+			return 1
+		}
+	}
+	if len(matches) == 0 {
+		return 0
+	}
+	agreeing := 0
+	for _, match := range matches {
+		if i < len(match.Posting) && match.Posting[i].Account == account {
+			agreeing++
+		}
+	}
+	return float64(agreeing) / float64(len(matches))
+}
+
+// reviewTransaction returns the transaction written for review, balanced with
+// the unknown account if needed and with the confidence in the comment.
+func reviewTransaction(transaction journal.Transaction, entry finance.StatementEntry, confidence float64) journal.Transaction {
+	if transaction.Date.IsZero() {
+		transaction.Date = entry.Date
+	}
+	postings := append([]journal.Posting{}, transaction.Posting...)
+	if len(postings) == 0 {
+		account := entry.Account
+		if account == "" {
+			account = UnknownAccount
+		}
+		postings = append(postings, journal.Posting{Account: account, Ammount: entry.Ammount.InvertSign()})
+	}
+	for _, ammount := range journal.PostingsBalance(postings).Ammounts() {
+		if !ammount.Quantity.IsZero() {
+			postings = append(postings, journal.Posting{Account: UnknownAccount, Ammount: ammount.InvertSign()})
+		}
+	}
+	transaction.Posting = postings
+	note := fmt.Sprintf("review: confidence %.0f%%", confidence*100)
+	if entry.Duplicate {
+		note = "review: duplicate"
+	}
+	if transaction.Comment != "" {
+		note += ", " + transaction.Comment
+	}
+	transaction.Comment = note
+	return transaction
+}
+
+func newPostingData(posting journal.Posting) *statemod.PostingData {
+	data := statemod.NewPostingData()
+	data.Account.Set(journal.Account(posting.Account))
+	data.Ammount.Set(posting.Ammount)
+	return data
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// New creates a new statement import service.
+func New(
+	state *statemod.State,
+	loader StatementLoader,
+	progressStore statementprogress.IStore,
+	guessers Guessers,
+	printer printer.IPrinter,
+) *Service {
+	return &Service{
+		state:         state,
+		loader:        loader,
+		progressStore: progressStore,
+		guessers:      guessers,
+		printer:       printer,
+	}
+}
@@ -0,0 +1,277 @@
+package statementimporter_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/vitorqb/addledger/internal/accountguesser"
+	"github.com/vitorqb/addledger/internal/ammountguesser"
+	"github.com/vitorqb/addledger/internal/dateguesser"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+	"github.com/vitorqb/addledger/internal/printer"
+	. "github.com/vitorqb/addledger/internal/services/statementimporter"
+	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/statementprogress"
+	"github.com/vitorqb/addledger/internal/statementreader"
+	"github.com/vitorqb/addledger/internal/stringmatcher"
+	"github.com/vitorqb/addledger/internal/transactionmatcher"
+	statementimporter_mock "github.com/vitorqb/addledger/mocks/statementimporter"
+)
+
+func TestStatementImporter(t *testing.T) {
+	source := statemod.StatementSource{Name: "statement.csv", Key: "key"}
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	eur := func(x string) finance.Ammount {
+		return finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString(x)}
+	}
+	type testcontext struct {
+		state   *statemod.State
+		loader  *statementimporter_mock.MockStatementLoader
+		store   *statementprogress.Store
+		output  *bytes.Buffer
+		service *Service
+		// load makes the loader load the entries into the state
+		load func(entries ...finance.StatementEntry)
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, c *testcontext)
+	}
+	testcases := []testcase{
+		{
+			name: "Fail to load statement",
+			run: func(t *testing.T, c *testcontext) {
+				c.loader.EXPECT().LoadFromFiles("file", "preset", "").Return(assert.AnError)
+				_, err := c.service.Import("file", "preset", "", Options{Output: c.output})
+				assert.ErrorContains(t, err, "failed to load statement")
+			},
+		},
+		{
+			name: "Imports entries with statement accounts",
+			run: func(t *testing.T, c *testcontext) {
+				c.load(finance.StatementEntry{
+					Account:        "assets:bank",
+					CounterAccount: "expenses:rent",
+					Date:           date,
+					Description:    "Rent",
+					Ammount:        eur("100"),
+				})
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 1)
+				assert.Len(t, result.Pending, 0)
+				assert.Equal(t, date, result.Imported[0].Date)
+				assert.Equal(t, "Rent", result.Imported[0].Description)
+				assert.Equal(t, []journal.Posting{
+					{Account: "assets:bank", Ammount: eur("-100")},
+					{Account: "expenses:rent", Ammount: eur("100")},
+				}, result.Imported[0].Posting)
+				assert.Contains(t, c.output.String(), "expenses:rent")
+				progress, found, err := c.store.Load(source.Key)
+				assert.Nil(t, err)
+				assert.True(t, found)
+				assert.Empty(t, progress.Remaining)
+			},
+		},
+		{
+			name: "Imports incoming entries with the statement account positive",
+			run: func(t *testing.T, c *testcontext) {
+				c.load(finance.StatementEntry{
+					Account:        "assets:bank",
+					CounterAccount: "income:salary",
+					Date:           date,
+					Description:    "Salary",
+					Ammount:        eur("-2500"),
+				})
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 1)
+				assert.Equal(t, []journal.Posting{
+					{Account: "assets:bank", Ammount: eur("2500")},
+					{Account: "income:salary", Ammount: eur("-2500")},
+				}, result.Imported[0].Posting)
+			},
+		},
+		{
+			name: "Imports withdrawals with splits read from a qif statement",
+			run: func(t *testing.T, c *testcontext) {
+				qif := "!Type:Bank\nD01/02/2023\nT-100\nPRENT AND FOOD\n" +
+					"Sexpenses:rent\n$-90\nSexpenses:groceries\n$-10\n^\n"
+				entries, err := statementreader.NewStatementReader().Read(
+					strings.NewReader(qif),
+					statementreader.WithFormat(statementreader.FormatQIF),
+					statementreader.WithAccountName("assets:bank"),
+					statementreader.WithDefaultCommodity("EUR"),
+					statementreader.WithDateFormats([]string{"01/02/2006"}),
+				)
+				assert.Nil(t, err)
+				c.load(entries...)
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 1)
+				assert.Equal(t, []journal.Posting{
+					{Account: "assets:bank", Ammount: eur("-100")},
+					{Account: "expenses:rent", Ammount: eur("90")},
+					{Account: "expenses:groceries", Ammount: eur("10")},
+				}, result.Imported[0].Posting)
+			},
+		},
+		{
+			name: "Imports entries matching the history",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.JournalMetadata.SetTransactions([]journal.Transaction{{
+					Description: "Supermarket",
+					Posting: []journal.Posting{
+						{Account: "assets:bank", Ammount: eur("-20")},
+						{Account: "expenses:groceries", Ammount: eur("20")},
+					},
+				}})
+				c.load(finance.StatementEntry{
+					Account:     "assets:bank",
+					Date:        date,
+					Description: "Supermarket",
+					Ammount:     eur("30"),
+				})
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 1)
+				assert.Equal(t, "expenses:groceries", result.Imported[0].Posting[1].Account)
+			},
+		},
+		{
+			name: "Leaves entries below the threshold to be resumed",
+			run: func(t *testing.T, c *testcontext) {
+				entry := finance.StatementEntry{
+					Account:     "assets:bank",
+					Date:        date,
+					Description: "Something new",
+					Ammount:     eur("30"),
+				}
+				c.load(entry)
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 0)
+				entry.Source = source.Name
+				assert.Equal(t, []finance.StatementEntry{entry}, result.Pending)
+				assert.Equal(t, "", c.output.String())
+				progress, found, err := c.store.Load(source.Key)
+				assert.Nil(t, err)
+				assert.True(t, found)
+				assert.Len(t, progress.Remaining, 1)
+				assert.Equal(t, "Something new", progress.Remaining[0].Description)
+			},
+		},
+		{
+			name: "Leaves duplicated entries to be resumed",
+			run: func(t *testing.T, c *testcontext) {
+				c.load(finance.StatementEntry{
+					Account:        "assets:bank",
+					CounterAccount: "expenses:rent",
+					Date:           date,
+					Description:    "Rent",
+					Ammount:        eur("100"),
+					Duplicate:      true,
+				})
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 0)
+				assert.Len(t, result.Pending, 1)
+			},
+		},
+		{
+			name: "Writes entries below the threshold for review",
+			run: func(t *testing.T, c *testcontext) {
+				c.load(finance.StatementEntry{
+					Account:     "assets:bank",
+					Date:        date,
+					Description: "Something new",
+					Ammount:     eur("30"),
+				})
+				review := new(bytes.Buffer)
+				options := Options{Threshold: 0.8, Output: c.output, Review: review}
+				result, err := c.service.Import("file", "", "", options)
+				assert.Nil(t, err)
+				assert.Len(t, result.Pending, 1)
+				assert.Equal(t, "", c.output.String())
+				assert.Contains(t, review.String(), "Something new")
+				assert.Contains(t, review.String(), "review: confidence 0%")
+				assert.Contains(t, review.String(), UnknownAccount)
+				progress, found, err := c.store.Load(source.Key)
+				assert.Nil(t, err)
+				assert.True(t, found)
+				assert.Empty(t, progress.Remaining)
+			},
+		},
+		{
+			name: "Imports only the remaining entries of a saved progress",
+			run: func(t *testing.T, c *testcontext) {
+				rent := finance.StatementEntry{
+					Account:        "assets:bank",
+					CounterAccount: "expenses:rent",
+					Date:           date,
+					Description:    "Rent",
+					Ammount:        eur("100"),
+				}
+				water := rent
+				water.Description = "Water"
+				water.CounterAccount = "expenses:water"
+				c.loader.EXPECT().LoadFromFiles(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_, _, _ string) error {
+						c.state.LoadStatement(source, []finance.StatementEntry{rent, water})
+						c.state.SetResumableStatementProgress(source.Name, statemod.StatementProgress{
+							Remaining: []finance.StatementEntry{water},
+						})
+						return nil
+					},
+				)
+				result, err := c.service.Import("file", "", "", Options{Threshold: 0.8, Output: c.output})
+				assert.Nil(t, err)
+				assert.Len(t, result.Imported, 1)
+				assert.Equal(t, "Water", result.Imported[0].Description)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := new(testcontext)
+			c.state = statemod.InitialState()
+			c.loader = statementimporter_mock.NewMockStatementLoader(ctrl)
+			c.store = statementprogress.New(t.TempDir())
+			c.output = new(bytes.Buffer)
+			c.load = func(entries ...finance.StatementEntry) {
+				c.loader.EXPECT().LoadFromFiles(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_, _, _ string) error {
+						c.state.LoadStatement(source, entries)
+						return nil
+					},
+				)
+			}
+			dateGuesser, err := dateguesser.New(dateguesser.StatementBase)
+			assert.Nil(t, err)
+			statementAccountGuesser, err := accountguesser.NewStatementAccountGuesser()
+			assert.Nil(t, err)
+			matchedAccountGuesser, err := accountguesser.NewMatchedTransactionsAccountGuesser()
+			assert.Nil(t, err)
+			accountGuesser, err := accountguesser.NewCompositeAccountGuesser(statementAccountGuesser, matchedAccountGuesser)
+			assert.Nil(t, err)
+			stringMatcher, err := stringmatcher.New(&stringmatcher.Options{})
+			assert.Nil(t, err)
+			guessers := Guessers{
+				Date:               dateGuesser,
+				Account:            accountGuesser,
+				Ammount:            ammountguesser.New(""),
+				TransactionMatcher: transactionmatcher.New(stringMatcher),
+			}
+			c.service = New(c.state, c.loader, c.store, guessers, printer.New(0, 0))
+			tc.run(t, c)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package statementloader
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/vitorqb/addledger/internal/statementreader"
 	"github.com/vitorqb/addledger/internal/utils"
 )
 
@@ -19,7 +21,13 @@ func expandUserHome(path string) string {
 
 type Config struct {
 	// File to load statement from.
-	File string
+	File string `json:"-"`
+	// Format of the statement file. Either empty (csv), csv, camt053 or qif.
+	Format string `json:"format"`
+	// Character encoding of the statement file (e.g. utf-8, utf-16le,
+	// utf-16be, iso-8859-1 or windows-1252). Empty means utf-8, unless the
+	// file starts with a BOM.
+	Encoding string `json:"encoding"`
 	// Separator to use.
 	Separator string `json:"separator"`
 	// Default account to use for all entries.
@@ -33,26 +41,124 @@ type Config struct {
 	DateFieldIndex int `json:"dateFieldIndex"`
 	// Date format to use for parsing the date field.
 	DateFormat string `json:"dateFormat"`
+	// Date formats to try, in order, when reading qif files. Defaults to
+	// DateFormat.
+	DateFormats []string `json:"dateFormats"`
 	// Index of the account field in the CSV file.
 	AccountFieldIndex int `json:"accountFieldIndex"`
 	// Index of the description field in the CSV file.
 	DescriptionFieldIndex int `json:"descriptionFieldIndex"`
 	// Index of the ammount field in the CSV file.
 	AmmountFieldIndex int `json:"ammountFieldIndex"`
+	// Whether the ammounts use a comma as decimal mark (e.g. `1.234,56`).
+	DecimalComma bool `json:"decimalComma"`
+	// Index of the field with the money coming in, for files that split the
+	// ammount in two columns.
+	AmmountInFieldIndex int `json:"ammountInFieldIndex"`
+	// Index of the field with the money going out, for files that split the
+	// ammount in two columns.
+	AmmountOutFieldIndex int `json:"ammountOutFieldIndex"`
+	// Index of extra fields in the CSV file, by name (e.g. a bank reference).
+	Fields map[string]int `json:"fields"`
+	// Names of the extra fields used as tags of the transaction.
+	TagFields []string `json:"tagFields"`
+	// Template for the transaction comment, where `%name` is replaced by the
+	// value of the extra field `name`.
+	Comment string `json:"comment"`
+	// Number of rows to skip at the beginning of the CSV file.
+	Skip int `json:"skip"`
+	// Whether to invert the sign of the ammounts, for statements where money
+	// going out is negative (e.g. most bank accounts).
+	InvertSign bool `json:"invertSign"`
+	// Index of a field (e.g. a debit/credit indicator) that inverts the sign
+	// of the ammount when its value is one of InvertSignValues.
+	InvertSignFieldIndex int `json:"invertSignFieldIndex"`
+	// Values of the InvertSignFieldIndex field that invert the sign of the
+	// ammount (case insensitive).
+	InvertSignValues []string `json:"invertSignValues"`
+	// Index of the field with the commodity of each entry, for multi
+	// currency accounts. Commodity is used for the entries where it's empty.
+	CommodityFieldIndex int `json:"commodityFieldIndex"`
+	// Rules to apply to the entries. As of now only read from hledger rules
+	// files.
+	Rules []Rule `json:"-"`
+	// Rules to rewrite the description of the entries, applied in order.
+	DescriptionRules []DescriptionRule `json:"descriptionRules"`
+	// Inference is how the config was inferred from the statement, if it
+	// was, so the user can be told how confident it is.
+	Inference *statementreader.Inference `json:"-"`
 }
 
+// DescriptionRule rewrites the matches of a (case insensitive) regex in the
+// description of the entries.
+type DescriptionRule struct {
+	// Regex to match in the description.
+	Pattern string `json:"pattern"`
+	// Replacement for the matches, where `$1` is the first submatch.
+	Replace string `json:"replace"`
+}
+
+// newConfig returns a Config with the default values.
+func newConfig() Config {
+	return Config{
+		AccountFieldIndex:     -1,
+		AmmountFieldIndex:     -1,
+		AmmountInFieldIndex:   -1,
+		AmmountOutFieldIndex:  -1,
+		DateFieldIndex:        -1,
+		DescriptionFieldIndex: -1,
+		InvertSignFieldIndex:  -1,
+		CommodityFieldIndex:   -1,
+		DateFormat:            "02/01/2006",
+	}
+}
+
+// AutoPreset is the preset name used to infer the config from the statement.
+const AutoPreset = "auto"
+
 type ConfigLoader struct {
 	PresetsDir string
+	// File with description rules applied to all statements, after the
+	// ones from the preset. Ignored if it does not exist.
+	DescriptionRulesFile string
 }
 
-func (cf *ConfigLoader) Load(file, preset string) (Config, error) {
+// Load loads the config for a statement file from a preset. If `format` is
+// not empty, it overrides the format defined in the preset.
+func (cf *ConfigLoader) Load(file, preset, format string) (Config, error) {
 	if file == "" {
 		return Config{}, nil
 	}
+	config, err := cf.loadPreset(file, preset, format)
+	if err != nil {
+		return Config{}, err
+	}
+	if format != "" {
+		config.Format = format
+	}
+	globalRules, err := cf.loadDescriptionRules()
+	if err != nil {
+		return Config{}, err
+	}
+	config.DescriptionRules = append(config.DescriptionRules, globalRules...)
+	return config, nil
+}
+
+// loadPreset loads the config for a statement file from a preset. The
+// config is inferred from csv statements without preset, while other formats
+// need none.
+func (cf *ConfigLoader) loadPreset(file, preset, format string) (Config, error) {
+	if preset == AutoPreset {
+		return inferConfig(file, format)
+	}
 	if preset == "" {
 		defaultPresetFile := filepath.Join(cf.PresetsDir, "default.json")
 		if _, err := os.Stat(defaultPresetFile); err != nil {
-			return Config{}, fmt.Errorf("missing preset (and no default defined)")
+			config, err := inferConfig(file, format)
+			if err != nil {
+				return Config{}, fmt.Errorf("missing preset (and no default defined): %w", err)
+			}
+			return config, nil
 		}
 		preset = defaultPresetFile
 	}
@@ -68,21 +174,117 @@ func (cf *ConfigLoader) Load(file, preset string) (Config, error) {
 		return Config{}, fmt.Errorf("failed to open preset file %s: %w", preset, err)
 	}
 	var config Config
-	config.AccountFieldIndex = -1
-	config.AmmountFieldIndex = -1
-	config.DateFieldIndex = -1
-	config.DescriptionFieldIndex = -1
-	config.DateFormat = "02/01/2006"
-	err = json.Unmarshal(presetBytes, &config)
-	if err != nil {
-		return Config{}, fmt.Errorf("failed to unmarshal preset file: %w", err)
+	if filepath.Ext(preset) == ".rules" {
+		config, err = ParseHledgerRules(presetBytes)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to parse rules file %s: %w", preset, err)
+		}
+	} else {
+		config = newConfig()
+		err = json.Unmarshal(presetBytes, &config)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to unmarshal preset file: %w", err)
+		}
 	}
 	config.File = expandUserHome(file)
 	return config, nil
 }
 
-func LoadConfig(file, preset string) (Config, error) {
-	presetsDir := filepath.Join(os.Getenv("HOME"), ".config/addledger/presets")
-	loader := ConfigLoader{presetsDir}
-	return loader.Load(file, preset)
+// loadDescriptionRules loads the global description rules, if any.
+func (cf *ConfigLoader) loadDescriptionRules() ([]DescriptionRule, error) {
+	if cf.DescriptionRulesFile == "" {
+		return nil, nil
+	}
+	rulesBytes, err := os.ReadFile(expandUserHome(cf.DescriptionRulesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open description rules file %s: %w", cf.DescriptionRulesFile, err)
+	}
+	var rules []DescriptionRule
+	if err := json.Unmarshal(rulesBytes, &rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal description rules file: %w", err)
+	}
+	return rules, nil
+}
+
+// inferConfig infers the config of a csv statement from its content.
+// Statements in other formats are read without config.
+func inferConfig(file, format string) (Config, error) {
+	file = expandUserHome(file)
+	if parsedFormat, err := statementreader.ParseFormat(format); err == nil && parsedFormat != statementreader.FormatCSV {
+		config := newConfig()
+		config.File = file
+		return config, nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to open statement file %s: %w", file, err)
+	}
+	config, inference, err := InferConfig(content)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to infer preset: %w", err)
+	}
+	config.File = file
+	config.Inference = &inference
+	return config, nil
+}
+
+// InferConfig infers the config of a csv statement from its content. The
+// inference is returned so the user can be told how confident it is.
+func InferConfig(content []byte) (Config, statementreader.Inference, error) {
+	inference, err := statementreader.InferCSV(content)
+	if err != nil {
+		return Config{}, inference, err
+	}
+	config := newConfig()
+	config.Encoding = string(inference.Encoding)
+	config.Separator = string(inference.Separator)
+	config.Skip = inference.SkipRows
+	config.DateFieldIndex = inference.DateColumn
+	config.DateFormat = inference.DateFormat
+	config.AmmountFieldIndex = inference.AmmountColumn
+	config.DecimalComma = inference.DecimalComma
+	config.DescriptionFieldIndex = inference.DescriptionColumn
+	return config, inference, nil
+}
+
+// Save saves a config as a json preset in the presets dir, returning the
+// path of the saved file.
+func (cf *ConfigLoader) Save(preset string, config Config) (string, error) {
+	if preset == "" {
+		return "", fmt.Errorf("missing preset name")
+	}
+	if filepath.Ext(preset) == "" {
+		preset += ".json"
+	}
+	presetFile := filepath.Join(cf.PresetsDir, filepath.Base(preset))
+	presetBytes, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal preset: %w", err)
+	}
+	if err := os.MkdirAll(cf.PresetsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create presets dir: %w", err)
+	}
+	if err := os.WriteFile(presetFile, presetBytes, 0644); err != nil {
+		return "", fmt.Errorf("failed to write preset file %s: %w", presetFile, err)
+	}
+	return presetFile, nil
+}
+
+// DefaultPresetsDir returns the directory where presets are stored.
+func DefaultPresetsDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config/addledger/presets")
+}
+
+// DefaultDescriptionRulesFile returns the file with the description rules
+// applied to all statements.
+func DefaultDescriptionRulesFile() string {
+	return filepath.Join(os.Getenv("HOME"), ".config/addledger/description-rules.json")
+}
+
+func LoadConfig(file, preset, format string) (Config, error) {
+	loader := ConfigLoader{PresetsDir: DefaultPresetsDir(), DescriptionRulesFile: DefaultDescriptionRulesFile()}
+	return loader.Load(file, preset, format)
 }
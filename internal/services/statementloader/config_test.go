@@ -1,6 +1,8 @@
 package statementloader_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,32 +16,32 @@ func TestLoadCsvStatementLoaderConfig(t *testing.T) {
 	fullPresetFile := testutils.TestDataPath(t, "csv_preset_full.json")
 
 	t.Run("No file", func(t *testing.T) {
-		config, err := LoadConfig("", "")
+		config, err := LoadConfig("", "", "")
 		assert.Equal(t, Config{}, config)
 		assert.NoError(t, err)
 	})
 
 	t.Run("No preset", func(t *testing.T) {
 		loader := ConfigLoader{PresetsDir: testutils.TestDataPath(t, "empty")}
-		config, err := loader.Load(csvFile, "")
+		config, err := loader.Load(csvFile, "", "")
 		assert.Equal(t, Config{}, config)
 		assert.ErrorContains(t, err, "missing preset")
 	})
 
 	t.Run("Preset not found", func(t *testing.T) {
-		config, err := LoadConfig(csvFile, "foo")
+		config, err := LoadConfig(csvFile, "foo", "")
 		assert.Equal(t, Config{}, config)
 		assert.ErrorContains(t, err, "failed to open preset file")
 	})
 
 	t.Run("Preset as file name loads from config dir", func(t *testing.T) {
 		t.Setenv("HOME", "/home/foo")
-		_, err := LoadConfig(csvFile, "foo")
+		_, err := LoadConfig(csvFile, "foo", "")
 		assert.ErrorContains(t, err, "/home/foo/.config/addledger/presets/foo.json")
 	})
 
 	t.Run("Minimal preset found", func(t *testing.T) {
-		config, err := LoadConfig(csvFile, minPresetFile)
+		config, err := LoadConfig(csvFile, minPresetFile, "")
 		assert.NoError(t, err)
 		assert.Equal(t, Config{
 			File:                  csvFile,
@@ -51,11 +53,15 @@ func TestLoadCsvStatementLoaderConfig(t *testing.T) {
 			DescriptionFieldIndex: -1,
 			AccountFieldIndex:     -1,
 			AmmountFieldIndex:     -1,
+			AmmountInFieldIndex:   -1,
+			AmmountOutFieldIndex:  -1,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
 		}, config)
 	})
 
 	t.Run("Full preset found", func(t *testing.T) {
-		config, err := LoadConfig(csvFile, fullPresetFile)
+		config, err := LoadConfig(csvFile, fullPresetFile, "")
 		assert.NoError(t, err)
 		assert.Equal(t, Config{
 			File:                  csvFile,
@@ -67,12 +73,16 @@ func TestLoadCsvStatementLoaderConfig(t *testing.T) {
 			DescriptionFieldIndex: 1,
 			AccountFieldIndex:     2,
 			AmmountFieldIndex:     3,
+			AmmountInFieldIndex:   -1,
+			AmmountOutFieldIndex:  -1,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
 		}, config)
 	})
 
 	t.Run("Uses default preset if available", func(t *testing.T) {
 		loader := ConfigLoader{PresetsDir: testutils.TestDataPath(t, "presets")}
-		config, err := loader.Load(csvFile, "")
+		config, err := loader.Load(csvFile, "", "")
 		assert.NoError(t, err)
 		assert.Equal(t, Config{
 			File:                  csvFile,
@@ -84,14 +94,53 @@ func TestLoadCsvStatementLoaderConfig(t *testing.T) {
 			DescriptionFieldIndex: 1,
 			AccountFieldIndex:     2,
 			AmmountFieldIndex:     3,
+			AmmountInFieldIndex:   -1,
+			AmmountOutFieldIndex:  -1,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
 		}, config)
 
 	})
 
+	t.Run("Loads hledger rules file", func(t *testing.T) {
+		config, err := LoadConfig(csvFile, testutils.TestDataPath(t, "bank.rules"), "")
+		assert.NoError(t, err)
+		assert.Equal(t, Config{
+			File:                  csvFile,
+			Separator:             ";",
+			Account:               "assets:bank",
+			Commodity:             "EUR",
+			Skip:                  1,
+			DateFormat:            "02/01/2006",
+			DateFieldIndex:        0,
+			DescriptionFieldIndex: 1,
+			AccountFieldIndex:     -1,
+			AmmountFieldIndex:     -1,
+			AmmountInFieldIndex:   3,
+			AmmountOutFieldIndex:  4,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
+			Rules: []Rule{
+				{
+					Conditions:     [][]RuleMatcher{{{Pattern: "SUPERMARKET", FieldIndex: -1}}},
+					CounterAccount: "expenses:groceries",
+				},
+				{
+					Conditions: [][]RuleMatcher{{
+						{Pattern: "^RENT", FieldIndex: 1},
+						{Pattern: "1000", FieldIndex: 4},
+					}},
+					CounterAccount: "expenses:rent",
+					Description:    "Rent %1",
+				},
+			},
+		}, config)
+	})
+
 	t.Run("Expands home dir", func(t *testing.T) {
 		t.Setenv("HOME", testutils.TestDataPath(t, ""))
 		l := ConfigLoader{}
-		config, err := l.Load("~/statement.csv", "~/csv_preset_min.json")
+		config, err := l.Load("~/statement.csv", "~/csv_preset_min.json", "")
 		assert.NoError(t, err)
 		assert.Equal(t, Config{
 			File:                  csvFile,
@@ -103,6 +152,143 @@ func TestLoadCsvStatementLoaderConfig(t *testing.T) {
 			DescriptionFieldIndex: -1,
 			AccountFieldIndex:     -1,
 			AmmountFieldIndex:     -1,
+			AmmountInFieldIndex:   -1,
+			AmmountOutFieldIndex:  -1,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
 		}, config)
 	})
 }
+
+func TestInferCsvStatementLoaderConfig(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "statement.csv")
+	content := "Date;Description;Value\n27/09/2023;PHARMACY;-8,50\n28/09/2023;SALARY;1.000,00\n"
+	err := os.WriteFile(csvFile, []byte(content), 0644)
+	assert.NoError(t, err)
+	expected := Config{
+		File:                  csvFile,
+		Separator:             ";",
+		Skip:                  1,
+		DateFormat:            "02/01/2006",
+		DateFieldIndex:        0,
+		DescriptionFieldIndex: 1,
+		AccountFieldIndex:     -1,
+		AmmountFieldIndex:     2,
+		DecimalComma:          true,
+		AmmountInFieldIndex:   -1,
+		AmmountOutFieldIndex:  -1,
+		InvertSignFieldIndex:  -1,
+		CommodityFieldIndex:   -1,
+	}
+
+	t.Run("Infers preset with auto", func(t *testing.T) {
+		loader := ConfigLoader{PresetsDir: testutils.TestDataPath(t, "presets")}
+		config, err := loader.Load(csvFile, AutoPreset, "")
+		assert.NoError(t, err)
+		assert.NotNil(t, config.Inference)
+		config.Inference = nil
+		assert.Equal(t, expected, config)
+	})
+
+	t.Run("Infers preset if no default", func(t *testing.T) {
+		loader := ConfigLoader{PresetsDir: testutils.TestDataPath(t, "empty")}
+		config, err := loader.Load(csvFile, "", "")
+		assert.NoError(t, err)
+		assert.NotNil(t, config.Inference)
+		config.Inference = nil
+		assert.Equal(t, expected, config)
+	})
+
+	t.Run("Does not infer preset for other formats", func(t *testing.T) {
+		loader := ConfigLoader{PresetsDir: testutils.TestDataPath(t, "empty")}
+		for _, preset := range []string{"", AutoPreset} {
+			config, err := loader.Load("not-a-file", preset, "qif")
+			assert.NoError(t, err)
+			assert.Equal(t, "qif", config.Format)
+			assert.Equal(t, "not-a-file", config.File)
+			assert.Nil(t, config.Inference)
+		}
+	})
+
+	t.Run("Fails to infer preset", func(t *testing.T) {
+		loader := ConfigLoader{PresetsDir: testutils.TestDataPath(t, "empty")}
+		_, err := loader.Load("not-a-file", AutoPreset, "")
+		assert.ErrorContains(t, err, "failed to open statement file")
+	})
+}
+
+func TestLoadDescriptionRules(t *testing.T) {
+	csvFile := testutils.TestDataPath(t, "statement.csv")
+	writePreset := func(t *testing.T, dir, content string) {
+		err := os.WriteFile(filepath.Join(dir, "mybank.json"), []byte(content), 0644)
+		assert.NoError(t, err)
+	}
+
+	t.Run("Appends global rules to the preset ones", func(t *testing.T) {
+		dir := t.TempDir()
+		writePreset(t, dir, `{"descriptionRules": [{"pattern": "[0-9]{4}X+[0-9]{4}", "replace": ""}]}`)
+		rulesFile := filepath.Join(dir, "description-rules.json")
+		err := os.WriteFile(rulesFile, []byte(`[{"pattern": ".*mercadona.*", "replace": "Mercadona"}]`), 0644)
+		assert.NoError(t, err)
+		loader := ConfigLoader{PresetsDir: dir, DescriptionRulesFile: rulesFile}
+		config, err := loader.Load(csvFile, "mybank", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []DescriptionRule{
+			{Pattern: "[0-9]{4}X+[0-9]{4}", Replace: ""},
+			{Pattern: ".*mercadona.*", Replace: "Mercadona"},
+		}, config.DescriptionRules)
+	})
+
+	t.Run("Ignores missing global rules file", func(t *testing.T) {
+		dir := t.TempDir()
+		writePreset(t, dir, `{}`)
+		loader := ConfigLoader{PresetsDir: dir, DescriptionRulesFile: filepath.Join(dir, "missing.json")}
+		config, err := loader.Load(csvFile, "mybank", "")
+		assert.NoError(t, err)
+		assert.Empty(t, config.DescriptionRules)
+	})
+
+	t.Run("Fails with invalid global rules file", func(t *testing.T) {
+		dir := t.TempDir()
+		writePreset(t, dir, `{}`)
+		rulesFile := filepath.Join(dir, "description-rules.json")
+		err := os.WriteFile(rulesFile, []byte(`{`), 0644)
+		assert.NoError(t, err)
+		loader := ConfigLoader{PresetsDir: dir, DescriptionRulesFile: rulesFile}
+		_, err = loader.Load(csvFile, "mybank", "")
+		assert.ErrorContains(t, err, "failed to unmarshal description rules file")
+	})
+}
+
+func TestSaveCsvStatementLoaderConfig(t *testing.T) {
+	t.Run("Saves and loads back", func(t *testing.T) {
+		loader := ConfigLoader{PresetsDir: filepath.Join(t.TempDir(), "presets")}
+		config := Config{
+			File:                  "statement.csv",
+			Separator:             ";",
+			DateFormat:            "2006-01-02",
+			DateFieldIndex:        0,
+			DescriptionFieldIndex: 1,
+			AccountFieldIndex:     -1,
+			AmmountFieldIndex:     2,
+			AmmountInFieldIndex:   -1,
+			AmmountOutFieldIndex:  -1,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
+			InvertSign:            true,
+		}
+		presetFile, err := loader.Save("mybank", config)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(loader.PresetsDir, "mybank.json"), presetFile)
+		loaded, err := loader.Load("other.csv", "mybank", "")
+		assert.NoError(t, err)
+		config.File = "other.csv"
+		assert.Equal(t, config, loaded)
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		loader := ConfigLoader{PresetsDir: t.TempDir()}
+		_, err := loader.Save("", Config{})
+		assert.ErrorContains(t, err, "missing preset name")
+	})
+}
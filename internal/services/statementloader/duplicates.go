@@ -0,0 +1,107 @@
+package statementloader
+
+import (
+	"strings"
+	"time"
+
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+	"github.com/vitorqb/addledger/internal/stringmatcher"
+)
+
+// DefaultDuplicateDateWindow is the default maximum difference between the
+// date of a statement entry and the date of a journal transaction for them to
+// be considered duplicates.
+const DefaultDuplicateDateWindow = 3 * 24 * time.Hour
+
+// DuplicateDetector finds statement entries that are probably already in the
+// journal.
+type DuplicateDetector struct {
+	// DateWindow is the maximum distance between the entry and the
+	// transaction dates.
+	DateWindow    time.Duration
+	stringMatcher stringmatcher.IStringMatcher
+}
+
+// MarkDuplicates sets `Duplicate` for all statement entries that have a
+// matching transaction. A transaction matches an entry if it has one of the
+// entry tags (e.g. a bank reference), or if it has a posting in the entry
+// account with the same ammount (with any sign), the dates are within the date
+// window, and either the dates are the same or the descriptions are similar.
+// Each transaction matches at most one entry.
+func (d *DuplicateDetector) MarkDuplicates(entries []finance.StatementEntry, transactions []journal.Transaction) {
+	used := make([]bool, len(transactions))
+	for i, entry := range entries {
+		entries[i].Duplicate = false
+		for j, transaction := range transactions {
+			if !used[j] && d.isDuplicate(entry, transaction) {
+				used[j] = true
+				entries[i].Duplicate = true
+				break
+			}
+		}
+	}
+}
+
+func (d *DuplicateDetector) isDuplicate(entry finance.StatementEntry, transaction journal.Transaction) bool {
+	if hasMatchingTag(entry, transaction) {
+		return true
+	}
+	if !hasMatchingPosting(entry, transaction) {
+		return false
+	}
+	dateDiff := entry.Date.Sub(transaction.Date)
+	if dateDiff < 0 {
+		dateDiff = -dateDiff
+	}
+	if dateDiff > d.DateWindow {
+		return false
+	}
+	return dateDiff == 0 || d.similarDescriptions(entry.Description, transaction.Description)
+}
+
+func hasMatchingPosting(entry finance.StatementEntry, transaction journal.Transaction) bool {
+	for _, posting := range transaction.Posting {
+		if posting.Account != entry.Account {
+			continue
+		}
+		if posting.Ammount.Equal(entry.Ammount) || posting.Ammount.Equal(entry.Ammount.InvertSign()) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMatchingTag(entry finance.StatementEntry, transaction journal.Transaction) bool {
+	for _, entryTag := range journal.StatementEntryTags(entry) {
+		for _, tag := range transaction.Tags {
+			if tag == entryTag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// similarDescriptions returns true if one description contains the other, or
+// if they differ in at most half of their characters.
+func (d *DuplicateDetector) similarDescriptions(a, b string) bool {
+	a, b = strings.ToLower(strings.TrimSpace(a)), strings.ToLower(strings.TrimSpace(b))
+	if a == "" || b == "" {
+		return false
+	}
+	if strings.Contains(a, b) || strings.Contains(b, a) {
+		return true
+	}
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	return d.stringMatcher.Distance(a, b) <= maxLen/2
+}
+
+// NewDuplicateDetector returns a new DuplicateDetector using the default
+// date window.
+func NewDuplicateDetector(stringMatcher stringmatcher.IStringMatcher) *DuplicateDetector {
+	return &DuplicateDetector{DateWindow: DefaultDuplicateDateWindow, stringMatcher: stringMatcher}
+}
@@ -0,0 +1,146 @@
+package statementloader_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
+	. "github.com/vitorqb/addledger/internal/services/statementloader"
+	"github.com/vitorqb/addledger/internal/stringmatcher"
+)
+
+func TestDuplicateDetector(t *testing.T) {
+	date := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	ammount := finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1221, -2)}
+	transaction := journal.Transaction{
+		Description: "Supermarket",
+		Date:        date,
+		Posting: []journal.Posting{
+			{Account: "expenses:groceries", Ammount: ammount},
+			{Account: "assets:bank", Ammount: ammount.InvertSign()},
+		},
+	}
+	entry := finance.StatementEntry{
+		Account:     "assets:bank",
+		Date:        date,
+		Description: "SUPERMARKET 1234",
+		Ammount:     ammount.InvertSign(),
+	}
+	type testcase struct {
+		name         string
+		entries      func() []finance.StatementEntry
+		transactions []journal.Transaction
+		expected     []bool
+	}
+	testcases := []testcase{
+		{
+			name:         "same account, ammount and date",
+			entries:      func() []finance.StatementEntry { return []finance.StatementEntry{entry} },
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{true},
+		},
+		{
+			name: "inverted ammount",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Ammount = ammount
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{true},
+		},
+		{
+			name: "different account",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Account = "assets:savings"
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{false},
+		},
+		{
+			name: "different ammount",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Ammount = finance.Ammount{Commodity: "EUR", Quantity: decimal.New(1, 0)}
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{false},
+		},
+		{
+			name: "within date window and similar description",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Date = date.Add(2 * 24 * time.Hour)
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{true},
+		},
+		{
+			name: "within date window but different description",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Date = date.Add(2 * 24 * time.Hour)
+				e.Description = "TRANSFER TO JOHN"
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{false},
+		},
+		{
+			name: "outside date window",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Date = date.Add(4 * 24 * time.Hour)
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{false},
+		},
+		{
+			name: "same tag",
+			entries: func() []finance.StatementEntry {
+				e := entry
+				e.Date = date.Add(10 * 24 * time.Hour)
+				e.Account = "assets:savings"
+				e.Fields = map[string]string{"bankref": "REF1"}
+				e.TagFields = []string{"bankref"}
+				return []finance.StatementEntry{e}
+			},
+			transactions: []journal.Transaction{func() journal.Transaction {
+				t := transaction
+				t.Tags = []journal.Tag{{Name: "bankref", Value: "REF1"}}
+				return t
+			}()},
+			expected: []bool{true},
+		},
+		{
+			name: "transaction matches a single entry",
+			entries: func() []finance.StatementEntry {
+				return []finance.StatementEntry{entry, entry}
+			},
+			transactions: []journal.Transaction{transaction},
+			expected:     []bool{true, false},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			stringMatcher, err := stringmatcher.New(&stringmatcher.Options{})
+			assert.NoError(t, err)
+			detector := NewDuplicateDetector(stringMatcher)
+			entries := tc.entries()
+			detector.MarkDuplicates(entries, tc.transactions)
+			duplicates := []bool{}
+			for _, e := range entries {
+				duplicates = append(duplicates, e.Duplicate)
+			}
+			assert.Equal(t, tc.expected, duplicates)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package statementloader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rule is a rule to apply to the statement entries, equivalent to an `if`
+// block of an hledger csv rules file.
+type Rule struct {
+	// Conditions are alternatives, and each of them is a list of matchers
+	// that must all match the csv record.
+	Conditions [][]RuleMatcher
+	// CounterAccount is the account suggested for the counter posting.
+	CounterAccount string
+	// Description is a template for the description, where `%N` is the
+	// (1-based) Nth field of the record.
+	Description string
+}
+
+// RuleMatcher is a (case insensitive) regex to match against a csv record.
+type RuleMatcher struct {
+	// Pattern is the regex.
+	Pattern string
+	// FieldIndex is the index of the field to match, or -1 for the entire
+	// record.
+	FieldIndex int
+}
+
+var hledgerFieldReferenceRegex = regexp.MustCompile(`%([A-Za-z0-9_-]+)`)
+
+// strftimeToGoLayout maps strftime directives to go time layouts.
+var strftimeToGoLayout = map[string]string{
+	"%Y":  "2006",
+	"%y":  "06",
+	"%m":  "01",
+	"%-m": "1",
+	"%d":  "02",
+	"%-d": "2",
+	"%e":  "_2",
+	"%b":  "Jan",
+	"%h":  "Jan",
+	"%B":  "January",
+	"%a":  "Mon",
+	"%A":  "Monday",
+	"%H":  "15",
+	"%-H": "15",
+	"%M":  "04",
+	"%S":  "05",
+	"%p":  "PM",
+	"%%":  "%",
+}
+
+var strftimeDirectiveRegex = regexp.MustCompile(`%-?.`)
+
+// hledgerRulesParser parses the subset of the hledger csv rules syntax that
+// maps to a Config: `skip`, `separator`, `fields`, `date-format`, `currency`,
+// top level field assignments and `if` blocks setting `account2` or
+// `description`. Other directives are ignored.
+type hledgerRulesParser struct {
+	config      Config
+	fieldNames  map[string]int
+	currentRule *Rule
+	// readingMatchers is true while reading the matchers of an if block,
+	// before any of its (indented) assignments.
+	readingMatchers bool
+}
+
+// ParseHledgerRules parses an hledger csv rules file into a Config.
+func ParseHledgerRules(content []byte) (Config, error) {
+	parser := hledgerRulesParser{
+		config:     newConfig(),
+		fieldNames: map[string]int{},
+	}
+	parser.config.DateFormat = "2006-01-02"
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if err := parser.parseLine(strings.TrimRight(scanner.Text(), "\r")); err != nil {
+			return Config{}, fmt.Errorf("invalid rules file (line %d): %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Config{}, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	parser.closeRule()
+	return parser.config, nil
+}
+
+func (p *hledgerRulesParser) parseLine(line string) error {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		p.closeRule()
+		return nil
+	}
+	if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "*") {
+		return nil
+	}
+	if p.currentRule != nil {
+		indented := line[0] == ' ' || line[0] == '\t'
+		if indented {
+			p.readingMatchers = false
+			return p.parseRuleAssignment(trimmed)
+		}
+		if p.readingMatchers {
+			return p.parseMatcher(trimmed)
+		}
+		p.closeRule()
+	}
+	name, value := splitDirective(trimmed)
+	switch name {
+	case "if":
+		p.currentRule = &Rule{}
+		p.readingMatchers = true
+		if value != "" {
+			return p.parseMatcher(value)
+		}
+		return nil
+	case "skip":
+		if value == "" {
+			p.config.Skip = 1
+			return nil
+		}
+		skip, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid skip: %s", value)
+		}
+		p.config.Skip = skip
+	case "separator":
+		switch strings.ToUpper(value) {
+		case "TAB":
+			p.config.Separator = "\t"
+		case "SPACE":
+			p.config.Separator = " "
+		default:
+			p.config.Separator = value
+		}
+	case "fields":
+		return p.parseFields(value)
+	case "date-format":
+		p.config.DateFormat = strftimeToGo(value)
+	case "currency":
+		if index, ok := p.fieldReference(value); ok {
+			p.config.CommodityFieldIndex = index
+			return nil
+		}
+		p.config.Commodity = value
+	case "encoding":
+		p.config.Encoding = value
+	case "decimal-mark":
+		p.config.DecimalComma = value == ","
+	default:
+		return p.parseTopLevelAssignment(name, value)
+	}
+	return nil
+}
+
+func (p *hledgerRulesParser) parseFields(value string) error {
+	for i, field := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if name == "" || name == "_" {
+			continue
+		}
+		p.fieldNames[name] = i
+		if !p.assignFieldIndex(name, i) {
+			// Other fields are kept as extra named fields of the entries.
+			if p.config.Fields == nil {
+				p.config.Fields = map[string]int{}
+			}
+			p.config.Fields[name] = i
+		}
+	}
+	return nil
+}
+
+// assignFieldIndex maps a field name to a column, if it's a field we know.
+// Returns false otherwise.
+func (p *hledgerRulesParser) assignFieldIndex(name string, index int) bool {
+	switch name {
+	case "date":
+		p.config.DateFieldIndex = index
+	case "description":
+		p.config.DescriptionFieldIndex = index
+	case "amount":
+		// hledger amounts are the ones of account1, positive for money
+		// coming in.
+		p.config.AmmountFieldIndex = index
+		p.config.InvertSign = true
+	case "amount-in":
+		p.config.AmmountInFieldIndex = index
+	case "amount-out":
+		p.config.AmmountOutFieldIndex = index
+	case "account1":
+		p.config.AccountFieldIndex = index
+	case "currency":
+		p.config.CommodityFieldIndex = index
+	default:
+		return false
+	}
+	return true
+}
+
+// parseTopLevelAssignment parses a field assignment outside of an if block.
+// Assignments of a single field reference (`amount %3`) map the field to the
+// column. `account1` with any other value sets the default account, and other
+// `description` or `account2` values are turned into rules that always match.
+func (p *hledgerRulesParser) parseTopLevelAssignment(name, value string) error {
+	if index, ok := p.fieldReference(value); ok {
+		p.assignFieldIndex(name, index)
+		return nil
+	}
+	switch name {
+	case "account1":
+		p.config.Account = value
+	case "account2":
+		p.config.Rules = append(p.config.Rules, Rule{CounterAccount: value})
+	case "description":
+		p.config.Rules = append(p.config.Rules, Rule{Description: p.resolveFieldNames(value)})
+	}
+	return nil
+}
+
+func (p *hledgerRulesParser) parseRuleAssignment(line string) error {
+	name, value := splitDirective(line)
+	switch name {
+	case "account2":
+		p.currentRule.CounterAccount = value
+	case "description":
+		p.currentRule.Description = p.resolveFieldNames(value)
+	}
+	return nil
+}
+
+// parseMatcher parses a matcher line of an if block. Lines starting with `&`
+// are combined (and) with the previous matcher, others are alternatives.
+func (p *hledgerRulesParser) parseMatcher(line string) error {
+	and := strings.HasPrefix(line, "&")
+	if and {
+		line = strings.TrimSpace(strings.TrimLeft(line, "&"))
+	}
+	matcher := RuleMatcher{Pattern: line, FieldIndex: -1}
+	if strings.HasPrefix(line, "%") {
+		ref, pattern := splitDirective(line)
+		index, ok := p.fieldReference(ref)
+		if !ok {
+			return fmt.Errorf("unknown field: %s", ref)
+		}
+		matcher = RuleMatcher{Pattern: pattern, FieldIndex: index}
+	}
+	if _, err := regexp.Compile(matcher.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", matcher.Pattern, err)
+	}
+	conditions := p.currentRule.Conditions
+	if and && len(conditions) > 0 {
+		conditions[len(conditions)-1] = append(conditions[len(conditions)-1], matcher)
+	} else {
+		p.currentRule.Conditions = append(conditions, []RuleMatcher{matcher})
+	}
+	return nil
+}
+
+func (p *hledgerRulesParser) closeRule() {
+	if p.currentRule != nil {
+		p.config.Rules = append(p.config.Rules, *p.currentRule)
+	}
+	p.currentRule = nil
+	p.readingMatchers = false
+}
+
+// fieldReference returns the (0-based) column referenced by a `%N` or a
+// `%name` string.
+func (p *hledgerRulesParser) fieldReference(value string) (int, bool) {
+	if hledgerFieldReferenceRegex.FindString(value) != value || value == "" {
+		return 0, false
+	}
+	ref := strings.ToLower(value[1:])
+	if index, err := strconv.Atoi(ref); err == nil {
+		return index - 1, index > 0
+	}
+	index, ok := p.fieldNames[ref]
+	return index, ok
+}
+
+// resolveFieldNames replaces `%name` references by `%N` ones.
+func (p *hledgerRulesParser) resolveFieldNames(template string) string {
+	return hledgerFieldReferenceRegex.ReplaceAllStringFunc(template, func(ref string) string {
+		if index, ok := p.fieldReference(ref); ok {
+			return "%" + strconv.Itoa(index+1)
+		}
+		return ref
+	})
+}
+
+func splitDirective(line string) (name, value string) {
+	i := strings.IndexAny(line, " \t")
+	if i == -1 {
+		return line, ""
+	}
+	return line[:i], strings.TrimSpace(line[i:])
+}
+
+func strftimeToGo(format string) string {
+	return strftimeDirectiveRegex.ReplaceAllStringFunc(format, func(directive string) string {
+		if layout, ok := strftimeToGoLayout[directive]; ok {
+			return layout
+		}
+		return directive
+	})
+}
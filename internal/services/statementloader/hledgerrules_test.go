@@ -0,0 +1,173 @@
+package statementloader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/services/statementloader"
+)
+
+func TestParseHledgerRules(t *testing.T) {
+	type testcase struct {
+		name          string
+		rules         string
+		expected      func() Config
+		expectedError string
+	}
+	defaultConfig := func() Config {
+		return Config{
+			DateFormat:            "2006-01-02",
+			DateFieldIndex:        -1,
+			DescriptionFieldIndex: -1,
+			AccountFieldIndex:     -1,
+			AmmountFieldIndex:     -1,
+			AmmountInFieldIndex:   -1,
+			AmmountOutFieldIndex:  -1,
+			InvertSignFieldIndex:  -1,
+			CommodityFieldIndex:   -1,
+		}
+	}
+	testcases := []testcase{
+		{
+			name:     "empty",
+			rules:    "",
+			expected: defaultConfig,
+		},
+		{
+			name: "top level directives",
+			rules: `# comment
+skip
+separator TAB
+fields date, _, description, amount
+date-format %-m/%-d/%y
+currency USD
+decimal-mark ,
+encoding windows-1252
+account1 assets:bank`,
+			expected: func() Config {
+				config := defaultConfig()
+				config.Skip = 1
+				config.Separator = "\t"
+				config.DateFieldIndex = 0
+				config.DescriptionFieldIndex = 2
+				config.AmmountFieldIndex = 3
+				config.InvertSign = true
+				config.DateFormat = "1/2/06"
+				config.Commodity = "USD"
+				config.DecimalComma = true
+				config.Encoding = "windows-1252"
+				config.Account = "assets:bank"
+				return config
+			},
+		},
+		{
+			name: "top level field assignments",
+			rules: `skip 2
+date %1
+amount-in %3
+amount-out %4
+description %2 - %5
+account2 expenses:unknown`,
+			expected: func() Config {
+				config := defaultConfig()
+				config.Skip = 2
+				config.DateFieldIndex = 0
+				config.AmmountInFieldIndex = 2
+				config.AmmountOutFieldIndex = 3
+				config.Rules = []Rule{
+					{Description: "%2 - %5"},
+					{CounterAccount: "expenses:unknown"},
+				}
+				return config
+			},
+		},
+		{
+			name: "currency field",
+			rules: `fields date, description, amount, currency
+currency EUR`,
+			expected: func() Config {
+				config := defaultConfig()
+				config.DateFieldIndex = 0
+				config.DescriptionFieldIndex = 1
+				config.AmmountFieldIndex = 2
+				config.InvertSign = true
+				config.CommodityFieldIndex = 3
+				config.Commodity = "EUR"
+				return config
+			},
+		},
+		{
+			name:  "currency field assignment",
+			rules: `currency %4`,
+			expected: func() Config {
+				config := defaultConfig()
+				config.CommodityFieldIndex = 3
+				return config
+			},
+		},
+		{
+			name: "if blocks",
+			rules: `fields date, desc, amount
+if FOO
+BAR
+  account2 expenses:foo
+if
+%desc ^BAZ
+& %amount ^-
+ description Baz %desc
+ account2 expenses:baz
+account1 assets:bank`,
+			expected: func() Config {
+				config := defaultConfig()
+				config.DateFieldIndex = 0
+				config.AmmountFieldIndex = 2
+				config.InvertSign = true
+				config.Fields = map[string]int{"desc": 1}
+				config.Account = "assets:bank"
+				config.Rules = []Rule{
+					{
+						Conditions: [][]RuleMatcher{
+							{{Pattern: "FOO", FieldIndex: -1}},
+							{{Pattern: "BAR", FieldIndex: -1}},
+						},
+						CounterAccount: "expenses:foo",
+					},
+					{
+						Conditions: [][]RuleMatcher{
+							{{Pattern: "^BAZ", FieldIndex: 1}, {Pattern: "^-", FieldIndex: 2}},
+						},
+						Description:    "Baz %2",
+						CounterAccount: "expenses:baz",
+					},
+				}
+				return config
+			},
+		},
+		{
+			name:          "invalid skip",
+			rules:         "skip foo",
+			expectedError: "invalid rules file (line 1): invalid skip: foo",
+		},
+		{
+			name:          "unknown field in matcher",
+			rules:         "if %foo bar\n  account2 baz",
+			expectedError: "unknown field: %foo",
+		},
+		{
+			name:          "invalid pattern",
+			rules:         "if (foo\n  account2 baz",
+			expectedError: "invalid pattern (foo",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := ParseHledgerRules([]byte(tc.rules))
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected(), config)
+		})
+	}
+}
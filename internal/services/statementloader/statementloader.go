@@ -1,21 +1,39 @@
 package statementloader
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/sirupsen/logrus"
+	"github.com/vitorqb/addledger/internal/finance"
 	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/statementprogress"
 	"github.com/vitorqb/addledger/internal/statementreader"
+	"github.com/vitorqb/addledger/internal/stringmatcher"
+	"github.com/vitorqb/addledger/internal/usermessenger"
 )
 
 // Service can be used to load a statement into the app state.
 type Service struct {
-	state  *statemod.State
-	reader statementreader.IStatementReader
+	state             *statemod.State
+	reader            statementreader.IStatementReader
+	duplicateDetector *DuplicateDetector
+	progressStore     statementprogress.IStore
+	userMessenger     usermessenger.IUserMessenger
+	presetsDir        string
+	// descriptionRulesFile has the description rules applied to all
+	// statements.
+	descriptionRulesFile string
 }
 
-// Load loads a statement into the app state.
+// Load loads a statement into the app state. The entries are merged with the
+// ones from other statements already loaded, replacing the entries from a
+// previous load of the same file.
 func (c *Service) Load(config Config) error {
 	if config.File == "" {
 		return nil
@@ -24,36 +42,164 @@ func (c *Service) Load(config Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to load csv statement loader: %w", err)
 	}
-	csvFile, err := os.Open(config.File)
+	content, err := os.ReadFile(config.File)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
 	}
-	defer csvFile.Close()
-	statmntEntries, err := c.reader.Read(csvFile, options...)
+	statmntEntries, err := c.reader.Read(bytes.NewReader(content), options...)
 	if err != nil {
 		return fmt.Errorf("failed to load statement: %w", err)
 	}
-	c.state.SetStatementEntries(statmntEntries)
+	c.duplicateDetector.MarkDuplicates(statmntEntries, c.state.JournalMetadata.Transactions())
+	key := statementprogress.Key(content)
+	// The resumable progress must be set before loading the statement, so the
+	// saved progress is not overwritten by the new entries. A progress that
+	// may be resumed for another statement is kept.
+	if progress, found := c.loadProgress(key, statmntEntries); found {
+		c.state.SetResumableStatementProgress(config.File, progress)
+	} else {
+		c.state.ClearResumableStatementProgressFrom(config.File)
+	}
+	c.state.LoadStatement(statemod.StatementSource{Name: config.File, Key: key}, statmntEntries)
 	return nil
 }
 
+// loadProgress loads the saved progress for a statement. A progress equal to
+// the freshly loaded entries is ignored, since there is nothing to resume.
+func (c *Service) loadProgress(key string, entries []finance.StatementEntry) (statemod.StatementProgress, bool) {
+	progress, found, err := c.progressStore.Load(key)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to load statement progress")
+		return statemod.StatementProgress{}, false
+	}
+	if !found || (len(progress.Discarded) == 0 && len(progress.Remaining) == len(entries)) {
+		return statemod.StatementProgress{}, false
+	}
+	return progress, true
+}
+
 // LoadFromFiles do the same as `Load` but reads the config from a json file.
-func (c *Service) LoadFromFiles(statementFile, presetFile string) error {
-	config, err := LoadConfig(statementFile, presetFile)
+// If `format` is not empty, it overrides the format defined in the preset.
+// If the config is inferred, the user is told how confident the inference is.
+func (c *Service) LoadFromFiles(statementFile, presetFile, format string) error {
+	loader := ConfigLoader{PresetsDir: c.presetsDir, DescriptionRulesFile: c.descriptionRulesFile}
+	config, err := loader.Load(statementFile, presetFile, format)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
-	return c.Load(config)
+	if err := c.Load(config); err != nil {
+		return err
+	}
+	if config.Inference != nil {
+		report := strings.ReplaceAll(config.Inference.String(), "\n", "; ")
+		c.userMessenger.Info(fmt.Sprintf(
+			"Inferred preset for %s (%.0f%% confident): %s",
+			config.File, config.Inference.Confidence()*100, report,
+		))
+	}
+	return nil
+}
+
+// Preview reads the first `n` rows and entries of a statement with a config,
+// without loading it into the app state. Errors reading the entries are set
+// in the preview, so the user can see the raw rows while fixing the config.
+func (c *Service) Preview(config Config, n int) (statemod.PresetPreview, error) {
+	content, err := os.ReadFile(expandUserHome(config.File))
+	if err != nil {
+		return statemod.PresetPreview{}, fmt.Errorf("failed to open file: %w", err)
+	}
+	options, err := ParseConfig(config)
+	if err != nil {
+		return statemod.PresetPreview{Rows: previewRows(content, "", config.Separator, n), Err: err}, nil
+	}
+	preview := statemod.PresetPreview{Rows: previewRows(content, config.Encoding, config.Separator, n)}
+	entries, err := c.reader.Read(bytes.NewReader(content), options...)
+	if err != nil {
+		preview.Err = err
+		return preview, nil
+	}
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	preview.Entries = entries
+	return preview, nil
 }
 
-// New creates a new StatementLoaderSvc.
-func New(state *statemod.State, reader statementreader.IStatementReader) *Service {
-	return &Service{state: state, reader: reader}
+// previewRows returns the first `n` csv rows of a file. Rows may have
+// different number of fields, and reading stops at the first invalid one.
+func previewRows(content []byte, encoding, separator string, n int) [][]string {
+	// An invalid encoding is reported when parsing the config.
+	parsedEncoding, _ := statementreader.ParseEncoding(encoding)
+	csvReader := csv.NewReader(statementreader.NewDecodingReader(bytes.NewReader(content), parsedEncoding))
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	if len(separator) == 1 {
+		csvReader.Comma = []rune(separator)[0]
+	}
+	rows := [][]string{}
+	for len(rows) < n {
+		row, err := csvReader.Read()
+		if err != nil {
+			break
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// SavePreset saves a config as a preset in the presets dir, returning the
+// path of the saved file.
+func (c *Service) SavePreset(name string, config Config) (string, error) {
+	loader := ConfigLoader{PresetsDir: c.presetsDir}
+	return loader.Save(name, config)
+}
+
+// New creates a new StatementLoaderSvc. The string matcher is used to compare
+// descriptions when looking for entries already in the journal, the
+// progress store to find saved progress for the loaded statements, and the
+// user messenger to tell the user how statements were loaded.
+func New(
+	state *statemod.State,
+	reader statementreader.IStatementReader,
+	stringMatcher stringmatcher.IStringMatcher,
+	progressStore statementprogress.IStore,
+	userMessenger usermessenger.IUserMessenger,
+) *Service {
+	return &Service{
+		state:                state,
+		reader:               reader,
+		duplicateDetector:    NewDuplicateDetector(stringMatcher),
+		progressStore:        progressStore,
+		userMessenger:        userMessenger,
+		presetsDir:           DefaultPresetsDir(),
+		descriptionRulesFile: DefaultDescriptionRulesFile(),
+	}
 }
 
 // ParseConfig parses a statement loader config into statemtn reader options.
 func ParseConfig(config Config) ([]statementreader.Option, error) {
 	options := []statementreader.Option{}
+	if formatStr := config.Format; formatStr != "" {
+		format, err := statementreader.ParseFormat(formatStr)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, statementreader.WithFormat(format))
+		if format == statementreader.FormatQIF {
+			dateFormats := config.DateFormats
+			if len(dateFormats) == 0 && config.DateFormat != "" {
+				dateFormats = []string{config.DateFormat}
+			}
+			options = append(options, statementreader.WithDateFormats(dateFormats))
+		}
+	}
+	if encodingStr := config.Encoding; encodingStr != "" {
+		encoding, err := statementreader.ParseEncoding(encodingStr)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, statementreader.WithEncoding(encoding))
+	}
 	if acc := config.Account; acc != "" {
 		options = append(options, statementreader.WithAccountName(acc))
 	}
@@ -66,6 +212,32 @@ func ParseConfig(config Config) ([]statementreader.Option, error) {
 		}
 		options = append(options, statementreader.WithSeparator([]rune(sep)[0]))
 	}
+	if skip := config.Skip; skip > 0 {
+		options = append(options, statementreader.WithSkipRows(skip))
+	}
+	if len(config.TagFields) > 0 {
+		options = append(options, statementreader.WithTagFields(config.TagFields))
+	}
+	if comment := config.Comment; comment != "" {
+		options = append(options, statementreader.WithComment(comment))
+	}
+	if config.InvertSign {
+		options = append(options, statementreader.WithInvertSign(true))
+	}
+	if len(config.Rules) > 0 {
+		rules, err := parseRules(config.Rules)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, statementreader.WithRules(rules))
+	}
+	if len(config.DescriptionRules) > 0 {
+		descriptionRules, err := parseDescriptionRules(config.DescriptionRules)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, statementreader.WithDescriptionRules(descriptionRules))
+	}
 	if sortByStr := config.SortBy; sortByStr != "" {
 		switch strings.ToLower(sortByStr) {
 		case "date":
@@ -91,9 +263,90 @@ func ParseConfig(config Config) ([]statementreader.Option, error) {
 	}
 	if iammount := config.AmmountFieldIndex; iammount != -1 {
 		mapping = append(mapping, statementreader.CSVColumnMapping{
-			Column: iammount, Importer: statementreader.AmmountImporter{},
+			Column: iammount, Importer: statementreader.AmmountImporter{DecimalComma: config.DecimalComma},
+		})
+	}
+	if iammountIn := config.AmmountInFieldIndex; iammountIn != -1 {
+		mapping = append(mapping, statementreader.CSVColumnMapping{
+			Column: iammountIn, Importer: statementreader.AmmountInImporter{DecimalComma: config.DecimalComma},
+		})
+	}
+	if iammountOut := config.AmmountOutFieldIndex; iammountOut != -1 {
+		mapping = append(mapping, statementreader.CSVColumnMapping{
+			Column: iammountOut, Importer: statementreader.AmmountOutImporter{DecimalComma: config.DecimalComma},
+		})
+	}
+	// The commodity and sign inversion are applied on the imported ammount, so
+	// they must come after the ammount mappings.
+	if icommodity := config.CommodityFieldIndex; icommodity != -1 {
+		mapping = append(mapping, statementreader.CSVColumnMapping{
+			Column: icommodity, Importer: statementreader.CommodityImporter{},
+		})
+	}
+	if values := config.InvertSignValues; len(values) > 0 {
+		if config.InvertSignFieldIndex == -1 {
+			return nil, fmt.Errorf("missing invertSignFieldIndex for invertSignValues")
+		}
+		mapping = append(mapping, statementreader.CSVColumnMapping{
+			Column: config.InvertSignFieldIndex, Importer: statementreader.InvertSignImporter{Values: values},
+		})
+	}
+	for _, name := range sortedKeys(config.Fields) {
+		mapping = append(mapping, statementreader.CSVColumnMapping{
+			Column: config.Fields[name], Importer: statementreader.NamedFieldImporter{Name: name},
 		})
 	}
 	options = append(options, statementreader.WithLoaderMapping(mapping))
 	return options, nil
 }
+
+// sortedKeys returns the keys of a map in a stable order.
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseRules compiles the config rules into statement reader rules. Patterns
+// are case insensitive.
+func parseRules(configRules []Rule) ([]statementreader.Rule, error) {
+	rules := make([]statementreader.Rule, 0, len(configRules))
+	for _, configRule := range configRules {
+		rule := statementreader.Rule{
+			CounterAccount: configRule.CounterAccount,
+			Description:    configRule.Description,
+		}
+		for _, configCondition := range configRule.Conditions {
+			condition := statementreader.RuleCondition{}
+			for _, configMatcher := range configCondition {
+				pattern, err := regexp.Compile("(?i)" + configMatcher.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid rule pattern %s: %w", configMatcher.Pattern, err)
+				}
+				condition = append(condition, statementreader.RuleMatcher{
+					Pattern: pattern, Column: configMatcher.FieldIndex,
+				})
+			}
+			rule.Conditions = append(rule.Conditions, condition)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseDescriptionRules compiles the config description rules into statement
+// reader description rules. Patterns are case insensitive.
+func parseDescriptionRules(configRules []DescriptionRule) ([]statementreader.DescriptionRule, error) {
+	rules := make([]statementreader.DescriptionRule, 0, len(configRules))
+	for _, configRule := range configRules {
+		pattern, err := regexp.Compile("(?i)" + configRule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid description rule pattern %s: %w", configRule.Pattern, err)
+		}
+		rules = append(rules, statementreader.DescriptionRule{Pattern: pattern, Replace: configRule.Replace})
+	}
+	return rules, nil
+}
@@ -1,24 +1,33 @@
 package statementloader_test
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/vitorqb/addledger/internal/finance"
+	"github.com/vitorqb/addledger/internal/journal"
 	. "github.com/vitorqb/addledger/internal/services/statementloader"
 	statemod "github.com/vitorqb/addledger/internal/state"
+	"github.com/vitorqb/addledger/internal/statementprogress"
 	"github.com/vitorqb/addledger/internal/statementreader"
+	"github.com/vitorqb/addledger/internal/stringmatcher"
 	"github.com/vitorqb/addledger/internal/testutils"
 	statementreader_mock "github.com/vitorqb/addledger/mocks/statementreader"
+	usermessenger_mock "github.com/vitorqb/addledger/mocks/usermessenger"
 )
 
 func TestStatementLoaderSvc(t *testing.T) {
 	statement := testutils.TestDataPath(t, "statement.csv")
 	type testcontext struct {
-		state   *statemod.State
-		reader  *statementreader_mock.MockIStatementReader
-		service *Service
+		state     *statemod.State
+		reader    *statementreader_mock.MockIStatementReader
+		store     *statementprogress.Store
+		messenger *usermessenger_mock.MockIUserMessenger
+		service   *Service
 	}
 	type testcase struct {
 		name string
@@ -50,7 +59,135 @@ func TestStatementLoaderSvc(t *testing.T) {
 				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(entries, nil)
 				err := c.service.Load(config)
 				assert.Nil(t, err)
-				assert.Equal(t, entries, c.state.GetStatementEntries())
+				assert.Equal(t, []finance.StatementEntry{{Account: "ACC", Source: statement}}, c.state.GetStatementEntries())
+			},
+		},
+		{
+			name: "Success merges statements from different files",
+			run: func(t *testing.T, c *testcontext) {
+				otherStatement := testutils.TestDataPath(t, "bank.rules")
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{{Account: "ACC1"}}, nil)
+				err := c.service.Load(Config{File: statement})
+				assert.Nil(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{{Account: "ACC2"}}, nil)
+				err = c.service.Load(Config{File: otherStatement})
+				assert.Nil(t, err)
+				assert.Equal(t, []finance.StatementEntry{
+					{Account: "ACC1", Source: statement},
+					{Account: "ACC2", Source: otherStatement},
+				}, c.state.GetStatementEntries())
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{{Account: "ACC3"}}, nil)
+				err = c.service.Load(Config{File: statement})
+				assert.Nil(t, err)
+				assert.Equal(t, []finance.StatementEntry{
+					{Account: "ACC2", Source: otherStatement},
+					{Account: "ACC3", Source: statement},
+				}, c.state.GetStatementEntries())
+			},
+		},
+		{
+			name: "Success sets statement source",
+			run: func(t *testing.T, c *testcontext) {
+				content, err := os.ReadFile(statement)
+				assert.NoError(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{}, nil)
+				err = c.service.Load(Config{File: statement})
+				assert.Nil(t, err)
+				assert.Equal(t, []statemod.StatementSource{
+					{Name: statement, Key: statementprogress.Key(content)},
+				}, c.state.StatementSources())
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Success with saved progress",
+			run: func(t *testing.T, c *testcontext) {
+				content, err := os.ReadFile(statement)
+				assert.NoError(t, err)
+				progress := statemod.StatementProgress{
+					Remaining: []finance.StatementEntry{testutils.StatementEntry_1(t)},
+					Discarded: []finance.StatementEntry{testutils.StatementEntry_2(t)},
+				}
+				err = c.store.Save(statementprogress.Key(content), progress)
+				assert.NoError(t, err)
+				entries := []finance.StatementEntry{{Account: "FOO"}, {Account: "BAR"}}
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(entries, nil)
+				err = c.service.Load(Config{File: statement})
+				assert.Nil(t, err)
+				assert.Len(t, c.state.GetStatementEntries(), 2)
+				resumable, found := c.state.ResumableStatementProgress()
+				assert.True(t, found)
+				assert.Len(t, resumable.Remaining, 1)
+				assert.Equal(t, "Description1", resumable.Remaining[0].Description)
+				assert.Len(t, resumable.Discarded, 1)
+				assert.Equal(t, "Description2", resumable.Discarded[0].Description)
+			},
+		},
+		{
+			name: "Success keeps the saved progress of another statement",
+			run: func(t *testing.T, c *testcontext) {
+				content, err := os.ReadFile(statement)
+				assert.NoError(t, err)
+				progress := statemod.StatementProgress{
+					Remaining: []finance.StatementEntry{testutils.StatementEntry_1(t)},
+					Discarded: []finance.StatementEntry{testutils.StatementEntry_2(t)},
+				}
+				err = c.store.Save(statementprogress.Key(content), progress)
+				assert.NoError(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{{Account: "FOO"}}, nil)
+				err = c.service.Load(Config{File: statement})
+				assert.Nil(t, err)
+				otherStatement := testutils.TestDataPath(t, "bank.rules")
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{{Account: "BAR"}}, nil)
+				err = c.service.Load(Config{File: otherStatement})
+				assert.Nil(t, err)
+				resumable, found := c.state.ResumableStatementProgress()
+				assert.True(t, found)
+				assert.Equal(t, "Description1", resumable.Remaining[0].Description)
+				c.state.ResumeStatementProgress()
+				assert.Equal(t,
+					[]finance.StatementEntry{{Account: "BAR", Source: otherStatement}},
+					c.state.StatementProgress(otherStatement).Remaining,
+				)
+			},
+		},
+		{
+			name: "Success with saved progress equal to statement",
+			run: func(t *testing.T, c *testcontext) {
+				content, err := os.ReadFile(statement)
+				assert.NoError(t, err)
+				entries := []finance.StatementEntry{{Account: "FOO"}}
+				progress := statemod.StatementProgress{Remaining: entries}
+				err = c.store.Save(statementprogress.Key(content), progress)
+				assert.NoError(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(entries, nil)
+				err = c.service.Load(Config{File: statement})
+				assert.Nil(t, err)
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Success marks duplicates",
+			run: func(t *testing.T, c *testcontext) {
+				transaction := testutils.Transaction_1(t)
+				c.state.JournalMetadata.SetTransactions([]journal.Transaction{*transaction})
+				entries := []finance.StatementEntry{
+					{
+						Account:     transaction.Posting[0].Account,
+						Date:        transaction.Date,
+						Description: transaction.Description,
+						Ammount:     transaction.Posting[0].Ammount,
+					},
+					{Account: "ACC"},
+				}
+				config := Config{File: statement}
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(entries, nil)
+				err := c.service.Load(config)
+				assert.Nil(t, err)
+				assert.True(t, c.state.GetStatementEntries()[0].Duplicate)
+				assert.False(t, c.state.GetStatementEntries()[1].Duplicate)
 			},
 		},
 		{
@@ -59,9 +196,109 @@ func TestStatementLoaderSvc(t *testing.T) {
 				entries := []finance.StatementEntry{{Account: "ACC"}}
 				presetFile := testutils.TestDataPath(t, "csv_preset_full.json")
 				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(entries, nil)
-				err := c.service.LoadFromFiles(statement, presetFile)
+				err := c.service.LoadFromFiles(statement, presetFile, "")
+				assert.Nil(t, err)
+				assert.Equal(t, []finance.StatementEntry{{Account: "ACC", Source: statement}}, c.state.GetStatementEntries())
+			},
+		},
+		{
+			name: "LoadFromFiles overrides format",
+			run: func(t *testing.T, c *testcontext) {
+				presetFile := testutils.TestDataPath(t, "csv_preset_full.json")
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, options ...statementreader.Option) ([]finance.StatementEntry, error) {
+						config := statementreader.Config{}
+						for _, option := range options {
+							option(&config)
+						}
+						assert.Equal(t, statementreader.FormatCAMT053, config.Format)
+						return []finance.StatementEntry{}, nil
+					},
+				)
+				err := c.service.LoadFromFiles(statement, presetFile, "camt053")
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "LoadFromFiles needs no preset for other formats",
+			run: func(t *testing.T, c *testcontext) {
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, options ...statementreader.Option) ([]finance.StatementEntry, error) {
+						config := statementreader.Config{}
+						for _, option := range options {
+							option(&config)
+						}
+						assert.Equal(t, statementreader.FormatQIF, config.Format)
+						return []finance.StatementEntry{{Account: "ACC"}}, nil
+					},
+				)
+				err := c.service.LoadFromFiles(statement, "", "qif")
+				assert.Nil(t, err)
+				assert.Len(t, c.state.GetStatementEntries(), 1)
+			},
+		},
+		{
+			name: "LoadFromFiles tells the user about the inferred preset",
+			run: func(t *testing.T, c *testcontext) {
+				file := filepath.Join(t.TempDir(), "statement.csv")
+				content := "Date;Description;Value\n27/09/2023;PHARMACY;-8,50\n28/09/2023;SALARY;1.000,00\n"
+				err := os.WriteFile(file, []byte(content), 0644)
+				assert.Nil(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return([]finance.StatementEntry{}, nil)
+				c.messenger.EXPECT().Info(gomock.Any()).Do(func(msg string) {
+					assert.Contains(t, msg, "Inferred preset for "+file)
+					assert.Contains(t, msg, "separator")
+				})
+				err = c.service.LoadFromFiles(file, "", "")
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Preview reads rows and entries",
+			run: func(t *testing.T, c *testcontext) {
+				file := filepath.Join(t.TempDir(), "statement.csv")
+				err := os.WriteFile(file, []byte("date;desc\n2023-01-01;FOO\n2023-01-02;BAR;X\n"), 0644)
+				assert.Nil(t, err)
+				entries := []finance.StatementEntry{{Description: "FOO"}, {Description: "BAR"}}
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(entries, nil)
+				preview, err := c.service.Preview(Config{File: file, Separator: ";"}, 2)
 				assert.Nil(t, err)
-				assert.Equal(t, entries, c.state.GetStatementEntries())
+				assert.Equal(t, [][]string{{"date", "desc"}, {"2023-01-01", "FOO"}}, preview.Rows)
+				assert.Equal(t, entries, preview.Entries)
+				assert.Nil(t, preview.Err)
+				assert.Empty(t, c.state.GetStatementEntries())
+			},
+		},
+		{
+			name: "Preview keeps rows when reading entries fails",
+			run: func(t *testing.T, c *testcontext) {
+				file := filepath.Join(t.TempDir(), "statement.csv")
+				err := os.WriteFile(file, []byte("a,b\n"), 0644)
+				assert.Nil(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+				preview, err := c.service.Preview(Config{File: file}, 10)
+				assert.Nil(t, err)
+				assert.Equal(t, [][]string{{"a", "b"}}, preview.Rows)
+				assert.ErrorIs(t, preview.Err, assert.AnError)
+			},
+		},
+		{
+			name: "Preview decodes rows",
+			run: func(t *testing.T, c *testcontext) {
+				file := filepath.Join(t.TempDir(), "statement.csv")
+				err := os.WriteFile(file, []byte("CAF\xc9,1\n"), 0644)
+				assert.Nil(t, err)
+				c.reader.EXPECT().Read(gomock.Any(), gomock.Any()).Return(nil, nil)
+				preview, err := c.service.Preview(Config{File: file, Encoding: "windows-1252"}, 10)
+				assert.Nil(t, err)
+				assert.Equal(t, [][]string{{"CAFÉ", "1"}}, preview.Rows)
+			},
+		},
+		{
+			name: "Preview fails to read file",
+			run: func(t *testing.T, c *testcontext) {
+				_, err := c.service.Preview(Config{File: "not-a-file"}, 10)
+				assert.ErrorContains(t, err, "failed to open file")
 			},
 		},
 	}
@@ -72,7 +309,13 @@ func TestStatementLoaderSvc(t *testing.T) {
 			c := new(testcontext)
 			c.state = statemod.InitialState()
 			c.reader = statementreader_mock.NewMockIStatementReader(ctrl)
-			c.service = New(c.state, c.reader)
+			stringMatcher, err := stringmatcher.New(&stringmatcher.Options{})
+			assert.NoError(t, err)
+			c.store = statementprogress.New(t.TempDir())
+			c.messenger = usermessenger_mock.NewMockIUserMessenger(ctrl)
+			// No presets nor description rules
+			t.Setenv("HOME", t.TempDir())
+			c.service = New(c.state, c.reader, stringMatcher, c.store, c.messenger)
 			tc.run(t, c)
 		})
 	}
@@ -93,6 +336,10 @@ func TestParseStatementLoaderConfig(t *testing.T) {
 				DescriptionFieldIndex: -1,
 				AccountFieldIndex:     -1,
 				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
 			},
 			expectedOptions: []statementreader.Option{
 				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
@@ -109,6 +356,10 @@ func TestParseStatementLoaderConfig(t *testing.T) {
 				DescriptionFieldIndex: 1,
 				AccountFieldIndex:     2,
 				AmmountFieldIndex:     3,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
 				SortBy:                "date",
 			},
 			expectedOptions: []statementreader.Option{
@@ -124,6 +375,256 @@ func TestParseStatementLoaderConfig(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "camt053 format",
+			config: Config{
+				Format:                "camt053",
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithFormat(statementreader.FormatCAMT053),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
+			},
+		},
+		{
+			name: "qif format defaults date formats to date format",
+			config: Config{
+				Format:                "qif",
+				DateFormat:            "01/02/2006",
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithFormat(statementreader.FormatQIF),
+				statementreader.WithDateFormats([]string{"01/02/2006"}),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
+			},
+		},
+		{
+			name: "qif format with date formats",
+			config: Config{
+				Format:                "qif",
+				DateFormat:            "01/02/2006",
+				DateFormats:           []string{"02/01/06", "02/01/2006"},
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithFormat(statementreader.FormatQIF),
+				statementreader.WithDateFormats([]string{"02/01/06", "02/01/2006"}),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
+			},
+		},
+		{
+			name: "hledger rules",
+			config: Config{
+				Skip:                  1,
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   2,
+				AmmountOutFieldIndex:  3,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+				Rules: []Rule{
+					{
+						Conditions:     [][]RuleMatcher{{{Pattern: "foo", FieldIndex: 1}}},
+						CounterAccount: "expenses:foo",
+					},
+				},
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithSkipRows(1),
+				statementreader.WithRules([]statementreader.Rule{
+					{
+						Conditions: []statementreader.RuleCondition{{
+							{Pattern: regexp.MustCompile("(?i)foo"), Column: 1},
+						}},
+						CounterAccount: "expenses:foo",
+					},
+				}),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{
+					{Column: 2, Importer: statementreader.AmmountInImporter{}},
+					{Column: 3, Importer: statementreader.AmmountOutImporter{}},
+				}),
+			},
+		},
+		{
+			name: "extra fields",
+			config: Config{
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+				Fields:                map[string]int{"memo": 5, "bankref": 4},
+				TagFields:             []string{"bankref"},
+				Comment:               "%memo",
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithTagFields([]string{"bankref"}),
+				statementreader.WithComment("%memo"),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{
+					{Column: 4, Importer: statementreader.NamedFieldImporter{Name: "bankref"}},
+					{Column: 5, Importer: statementreader.NamedFieldImporter{Name: "memo"}},
+				}),
+			},
+		},
+		{
+			name: "decimal comma",
+			config: Config{
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     2,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+				DecimalComma:          true,
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{
+					{Column: 2, Importer: statementreader.AmmountImporter{DecimalComma: true}},
+				}),
+			},
+		},
+		{
+			name: "invert sign",
+			config: Config{
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+				InvertSign:            true,
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithInvertSign(true),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
+			},
+		},
+		{
+			name: "invalid rule pattern",
+			config: Config{
+				Rules: []Rule{{Conditions: [][]RuleMatcher{{{Pattern: "(foo"}}}}},
+			},
+			expectedError: "invalid rule pattern (foo",
+		},
+		{
+			name: "description rules",
+			config: Config{
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+				DescriptionRules:      []DescriptionRule{{Pattern: "card [0-9]+", Replace: ""}},
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithDescriptionRules([]statementreader.DescriptionRule{
+					{Pattern: regexp.MustCompile("(?i)card [0-9]+"), Replace: ""},
+				}),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
+			},
+		},
+		{
+			name: "invalid description rule pattern",
+			config: Config{
+				DescriptionRules: []DescriptionRule{{Pattern: "(foo"}},
+			},
+			expectedError: "invalid description rule pattern (foo",
+		},
+		{
+			name: "commodity and invert sign fields",
+			config: Config{
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  3,
+				InvertSignValues:      []string{"D"},
+				CommodityFieldIndex:   2,
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{
+					{Column: 1, Importer: statementreader.AmmountImporter{}},
+					{Column: 2, Importer: statementreader.CommodityImporter{}},
+					{Column: 3, Importer: statementreader.InvertSignImporter{Values: []string{"D"}}},
+				}),
+			},
+		},
+		{
+			name: "invert sign values without field",
+			config: Config{
+				InvertSignFieldIndex: -1,
+				InvertSignValues:     []string{"D"},
+			},
+			expectedError: "missing invertSignFieldIndex for invertSignValues",
+		},
+		{
+			name: "encoding",
+			config: Config{
+				DateFieldIndex:        -1,
+				DescriptionFieldIndex: -1,
+				AccountFieldIndex:     -1,
+				AmmountFieldIndex:     -1,
+				AmmountInFieldIndex:   -1,
+				AmmountOutFieldIndex:  -1,
+				InvertSignFieldIndex:  -1,
+				CommodityFieldIndex:   -1,
+				Encoding:              "latin1",
+			},
+			expectedOptions: []statementreader.Option{
+				statementreader.WithEncoding(statementreader.EncodingISO88591),
+				statementreader.WithLoaderMapping([]statementreader.CSVColumnMapping{}),
+			},
+		},
+		{
+			name: "invalid encoding",
+			config: Config{
+				Encoding: "foo",
+			},
+			expectedError: "invalid statement encoding: foo",
+		},
+		{
+			name: "invalid format",
+			config: Config{
+				Format: "foo",
+			},
+			expectedError: "invalid statement format: foo",
+		},
 		{
 			name: "invalid sortBy",
 			config: Config{
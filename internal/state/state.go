@@ -1,8 +1,10 @@
 package state
 
 import (
+	"sort"
 	"time"
 
+	"github.com/vitorqb/addledger/internal/accountguesser"
 	"github.com/vitorqb/addledger/internal/finance"
 	"github.com/vitorqb/addledger/internal/journal"
 	"github.com/vitorqb/addledger/internal/utils"
@@ -19,6 +21,10 @@ type (
 		transactions []journal.Transaction
 		// accounts is a list of all known accounts
 		accounts []journal.Account
+		// defaultCommodity is the commodity declared as default in the journal
+		defaultCommodity string
+		// accountCommodities caches the most used commodity by account
+		accountCommodities map[journal.Account]string
 	}
 
 	// InputMetadata is the state relative to inputs.
@@ -31,6 +37,9 @@ type (
 		// Controls posting account
 		postingAccountText  string
 		postingAccountGuess *MaybeValue[journal.Account]
+		// postingAccountCandidates are the accounts suggested for the
+		// posting, best first, with their scores.
+		postingAccountCandidates []accountguesser.Candidate
 
 		// Controls posting ammount
 		postingAmmountGuess *MaybeValue[finance.Ammount]
@@ -44,6 +53,9 @@ type (
 		// Controls date
 		dateGuess *MaybeValue[time.Time]
 		dateText  string
+		// dateInterpretation describes how the date text was interpreted, or
+		// why it is not a valid date.
+		dateInterpretation string
 
 		// The transactions that match the current input
 		matchingTransactions []journal.Transaction
@@ -54,6 +66,27 @@ type (
 		react.IReact
 		visible        bool
 		defaultCsvFile string
+		// editingEntry is the index of the statement entry being edited, or
+		// -1 if no entry is being edited.
+		editingEntry int
+	}
+
+	// PresetBuilder is the state of the screen used to build statement
+	// presets.
+	PresetBuilder struct {
+		react.IReact
+		visible bool
+		preview PresetPreview
+	}
+
+	// PresetPreview is a preview of a statement read with a preset.
+	PresetPreview struct {
+		// Rows are the first raw rows of the statement file.
+		Rows [][]string
+		// Entries are the first statement entries read with the preset.
+		Entries []finance.StatementEntry
+		// Err is the error reading the entries with the preset, if any.
+		Err error
 	}
 
 	// Display is the state relative to the display.
@@ -63,6 +96,9 @@ type (
 		// Control the statement modal
 		StatementModal *StatementModal
 
+		// Control the preset builder
+		PresetBuilder *PresetBuilder
+
 		// Controls whether the shortcut modal is displayed or not
 		shortcutModal      bool
 		loadStatementModal bool
@@ -82,6 +118,39 @@ type (
 		// They are used to help the user to create journal entries.
 		StatementEntries []finance.StatementEntry
 		Display          *Display
+		// currentStatementIndex is the index of the current statement entry,
+		// the one used to help the user to create the journal entry.
+		currentStatementIndex int
+		// statementSources are the statements whose entries were loaded.
+		statementSources []StatementSource
+		// discardedStatementEntries are the entries discarded by the user.
+		discardedStatementEntries []finance.StatementEntry
+		// statementVersion is incremented every time the statement entries
+		// change, so we know when the progress must be saved.
+		statementVersion int
+		// resumableStatementProgress is a saved progress for the last loaded
+		// statement that the user may want to resume.
+		resumableStatementProgress *StatementProgress
+		// resumableStatementSource is the name of the source the resumable
+		// progress refers to.
+		resumableStatementSource string
+	}
+
+	// StatementSource is a statement loaded into the state.
+	StatementSource struct {
+		// Name identifies the source (e.g. the statement file), and is set
+		// as the `Source` of the loaded entries.
+		Name string
+		// Key identifies the statement content (e.g. a hash of the file).
+		Key string
+	}
+
+	// StatementProgress is the progress of the user on a loaded statement.
+	StatementProgress struct {
+		// Remaining are the entries not yet used nor discarded.
+		Remaining []finance.StatementEntry
+		// Discarded are the entries discarded by the user.
+		Discarded []finance.StatementEntry
 	}
 
 	// MaybeValue is a helper container that may contain a value or not
@@ -282,6 +351,19 @@ func (im *InputMetadata) ClearPostingAccountGuess() {
 	im.NotifyChange()
 }
 
+// PostingAccountCandidates returns the accounts suggested for the
+// PostingAccount input, best first.
+func (im *InputMetadata) PostingAccountCandidates() []accountguesser.Candidate {
+	return im.postingAccountCandidates
+}
+
+// SetPostingAccountCandidates sets the accounts suggested for the
+// PostingAccount input, best first.
+func (im *InputMetadata) SetPostingAccountCandidates(x []accountguesser.Candidate) {
+	im.postingAccountCandidates = x
+	im.NotifyChange()
+}
+
 // SelectedPostingAccount returns the current text for the selected account in the
 // context's AccountList.
 func (im *InputMetadata) SelectedPostingAccount() string {
@@ -382,6 +464,19 @@ func (im *InputMetadata) ClearDateGuess() {
 	im.NotifyChange()
 }
 
+// DateInterpretation returns how the date text was interpreted
+func (im *InputMetadata) DateInterpretation() string {
+	return im.dateInterpretation
+}
+
+// SetDateInterpretation sets how the date text was interpreted
+func (im *InputMetadata) SetDateInterpretation(x string) {
+	if im.dateInterpretation != x {
+		im.dateInterpretation = x
+		im.NotifyChange()
+	}
+}
+
 // GetDateText returns the current text for the date input
 func (im *InputMetadata) GetDateText() string {
 	return im.dateText
@@ -449,6 +544,7 @@ func (im *InputMetadata) Reset() {
 	im.postingAmmountInput = &MaybeValue[finance.Ammount]{}
 	im.postingAmmountText = ""
 	im.dateGuess = &MaybeValue[time.Time]{}
+	im.dateInterpretation = ""
 	im.NotifyChange()
 }
 
@@ -457,6 +553,8 @@ func NewJournalMetadata() *JournalMetadata {
 		react.New(),
 		[]journal.Transaction{},
 		[]journal.Account{},
+		"",
+		nil,
 	}
 }
 
@@ -466,12 +564,14 @@ func (jm *JournalMetadata) Transactions() []journal.Transaction { return jm.tran
 // SetTransactions sets all known postings for the journal
 func (jm *JournalMetadata) SetTransactions(x []journal.Transaction) {
 	jm.transactions = x
+	jm.accountCommodities = nil
 	jm.NotifyChange()
 }
 
 // AppendTransaction appends a transaction to the JournalMetadata.
 func (jm *JournalMetadata) AppendTransaction(x journal.Transaction) {
 	jm.transactions = append(jm.transactions, x)
+	jm.accountCommodities = nil
 	jm.NotifyChange()
 }
 
@@ -484,6 +584,48 @@ func (jm *JournalMetadata) SetAccounts(x []journal.Account) {
 	jm.NotifyChange()
 }
 
+// DefaultCommodity returns the commodity declared as default in the journal,
+// or an empty string if there is none.
+func (jm *JournalMetadata) DefaultCommodity() string { return jm.defaultCommodity }
+
+// SetDefaultCommodity sets the commodity declared as default in the journal
+func (jm *JournalMetadata) SetDefaultCommodity(x string) {
+	jm.defaultCommodity = x
+	jm.NotifyChange()
+}
+
+// AccountCommodity returns the commodity most used in the postings of an
+// account. Ties are broken alphabetically.
+func (jm *JournalMetadata) AccountCommodity(account journal.Account) (string, bool) {
+	if jm.accountCommodities == nil {
+		counts := map[journal.Account]map[string]int{}
+		for _, transaction := range jm.transactions {
+			for _, posting := range transaction.Posting {
+				if posting.Ammount.Commodity == "" {
+					continue
+				}
+				postingAccount := journal.Account(posting.Account)
+				if counts[postingAccount] == nil {
+					counts[postingAccount] = map[string]int{}
+				}
+				counts[postingAccount][posting.Ammount.Commodity]++
+			}
+		}
+		jm.accountCommodities = map[journal.Account]string{}
+		for postingAccount, commodityCounts := range counts {
+			best := ""
+			for commodity, count := range commodityCounts {
+				if best == "" || count > commodityCounts[best] || (count == commodityCounts[best] && commodity < best) {
+					best = commodity
+				}
+			}
+			jm.accountCommodities[postingAccount] = best
+		}
+	}
+	commodity, found := jm.accountCommodities[account]
+	return commodity, found
+}
+
 // Tags returns all known tags for the journal
 func (jm *JournalMetadata) Tags() []journal.Tag {
 	tags := []journal.Tag{}
@@ -498,47 +640,281 @@ func (s *State) GetStatementEntries() []finance.StatementEntry {
 	return s.StatementEntries
 }
 
-// SetStatementEntries sets the current statement entries
+// SetStatementEntries sets the current statement entries. The current entry
+// stays the current one if it is still there, otherwise the first entry
+// becomes the current one.
 func (s *State) SetStatementEntries(x []finance.StatementEntry) {
+	current, found := s.CurrentStatementEntry()
 	s.StatementEntries = x
+	s.currentStatementIndex = 0
+	if found {
+		for i, entry := range x {
+			if sameStatementEntry(entry, current) {
+				s.currentStatementIndex = i
+				break
+			}
+		}
+	}
+	s.statementVersion++
+	s.NotifyChange()
+}
+
+// sameStatementEntry returns whether two statement entries are the same entry,
+// even if one of them was saved and loaded back (e.g. in a statement
+// progress).
+func sameStatementEntry(a, b finance.StatementEntry) bool {
+	return a.Source == b.Source &&
+		a.Account == b.Account &&
+		a.Date.Equal(b.Date) &&
+		a.Description == b.Description &&
+		a.Ammount.Equal(b.Ammount)
+}
+
+// LoadStatement merges the entries of a statement into the statement
+// entries. If a statement from the same source was already loaded, its
+// entries (including discarded ones) are replaced. When more than one
+// source is loaded, the entries are sorted by date.
+func (s *State) LoadStatement(source StatementSource, x []finance.StatementEntry) {
+	entries := make([]finance.StatementEntry, len(x))
+	for i, entry := range x {
+		entry.Source = source.Name
+		entries[i] = entry
+	}
+	s.statementSources = append(removeStatementSource(s.statementSources, source.Name), source)
+	s.discardedStatementEntries = removeStatementEntriesFrom(s.discardedStatementEntries, source.Name)
+	s.setMergedStatementEntries(source.Name, entries)
+}
+
+// UnloadStatement removes all entries from a source, keeping the others.
+func (s *State) UnloadStatement(name string) {
+	s.statementSources = removeStatementSource(s.statementSources, name)
+	s.discardedStatementEntries = removeStatementEntriesFrom(s.discardedStatementEntries, name)
+	if s.resumableStatementSource == name {
+		s.resumableStatementProgress = nil
+		s.resumableStatementSource = ""
+	}
+	s.SetStatementEntries(removeStatementEntriesFrom(s.StatementEntries, name))
+}
+
+// StatementSources returns the loaded statements, in the order they were
+// loaded.
+func (s *State) StatementSources() []StatementSource { return s.statementSources }
+
+// StatementVersion returns a number that changes every time the statement
+// entries change.
+func (s *State) StatementVersion() int { return s.statementVersion }
+
+// StatementProgress returns the progress on the statement from a source.
+func (s *State) StatementProgress(name string) StatementProgress {
+	return StatementProgress{
+		Remaining: statementEntriesFrom(s.StatementEntries, name),
+		Discarded: statementEntriesFrom(s.discardedStatementEntries, name),
+	}
+}
+
+// ResumableStatementProgress returns a saved progress for the last loaded
+// statement that may be resumed.
+func (s *State) ResumableStatementProgress() (StatementProgress, bool) {
+	if s.resumableStatementProgress == nil {
+		return StatementProgress{}, false
+	}
+	return *s.resumableStatementProgress, true
+}
+
+// SetResumableStatementProgress sets a saved progress for the statement from
+// a source that may be resumed.
+func (s *State) SetResumableStatementProgress(name string, x StatementProgress) {
+	s.resumableStatementProgress = &x
+	s.resumableStatementSource = name
 	s.NotifyChange()
 }
 
+// ClearResumableStatementProgress clears the resumable statement progress.
+func (s *State) ClearResumableStatementProgress() {
+	if s.resumableStatementProgress != nil {
+		s.resumableStatementProgress = nil
+		s.resumableStatementSource = ""
+		s.NotifyChange()
+	}
+}
+
+// ClearResumableStatementProgressFrom clears the resumable statement progress
+// if it's for the statement from a source, keeping the one of other sources.
+func (s *State) ClearResumableStatementProgressFrom(name string) {
+	if s.resumableStatementSource == name {
+		s.ClearResumableStatementProgress()
+	}
+}
+
+// ResumeStatementProgress replaces the statement entries from the source of
+// the resumable statement progress by the ones from it, and clears it.
+func (s *State) ResumeStatementProgress() {
+	if s.resumableStatementProgress == nil {
+		return
+	}
+	progress, name := *s.resumableStatementProgress, s.resumableStatementSource
+	s.resumableStatementProgress = nil
+	s.resumableStatementSource = ""
+	discarded := removeStatementEntriesFrom(s.discardedStatementEntries, name)
+	for _, entry := range progress.Discarded {
+		entry.Source = name
+		discarded = append(discarded, entry)
+	}
+	s.discardedStatementEntries = discarded
+	remaining := make([]finance.StatementEntry, len(progress.Remaining))
+	for i, entry := range progress.Remaining {
+		entry.Source = name
+		remaining[i] = entry
+	}
+	s.setMergedStatementEntries(name, remaining)
+}
+
+// setMergedStatementEntries replaces the statement entries from a source.
+func (s *State) setMergedStatementEntries(name string, x []finance.StatementEntry) {
+	entries := append(removeStatementEntriesFrom(s.StatementEntries, name), x...)
+	if len(s.statementSources) > 1 {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Date.Before(entries[j].Date)
+		})
+	}
+	s.SetStatementEntries(entries)
+}
+
+func statementEntriesFrom(entries []finance.StatementEntry, name string) []finance.StatementEntry {
+	result := []finance.StatementEntry{}
+	for _, entry := range entries {
+		if entry.Source == name {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func removeStatementEntriesFrom(entries []finance.StatementEntry, name string) []finance.StatementEntry {
+	result := []finance.StatementEntry{}
+	for _, entry := range entries {
+		if entry.Source != name {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func removeStatementSource(sources []StatementSource, name string) []StatementSource {
+	result := []StatementSource{}
+	for _, source := range sources {
+		if source.Name != name {
+			result = append(result, source)
+		}
+	}
+	return result
+}
+
 // CurrentStatementEntry returns the current statement entry
 func (s *State) CurrentStatementEntry() (e finance.StatementEntry, found bool) {
 	if len(s.StatementEntries) == 0 {
 		return finance.StatementEntry{}, false
 	}
-	return s.StatementEntries[0], true
+	return s.StatementEntries[s.CurrentStatementIndex()], true
+}
+
+// CurrentStatementIndex returns the index of the current statement entry.
+// Defaults to the first entry.
+func (s *State) CurrentStatementIndex() int {
+	if s.currentStatementIndex >= len(s.StatementEntries) {
+		return 0
+	}
+	return s.currentStatementIndex
+}
+
+// SetCurrentStatementEntry makes the statement entry at an index the current
+// one.
+func (s *State) SetCurrentStatementEntry(i int) {
+	if i < 0 || i >= len(s.StatementEntries) {
+		return
+	}
+	s.currentStatementIndex = i
+	s.NotifyChange()
 }
 
-// PopStatementEntry pops the current statement entry
+// PopStatementEntry pops the current statement entry. The first entry
+// becomes the current one.
 func (s *State) PopStatementEntry() {
 	if len(s.StatementEntries) > 0 {
-		s.StatementEntries = s.StatementEntries[1:]
+		i := s.CurrentStatementIndex()
+		s.StatementEntries = append(s.StatementEntries[:i:i], s.StatementEntries[i+1:]...)
+		s.currentStatementIndex = 0
+		s.statementVersion++
 		s.NotifyChange()
 	}
 }
 
+// UpdateStatementEntry replaces a statement entry by index
+func (s *State) UpdateStatementEntry(i int, x finance.StatementEntry) {
+	if i < 0 || i >= len(s.StatementEntries) {
+		return
+	}
+	entries := append([]finance.StatementEntry{}, s.StatementEntries...)
+	entries[i] = x
+	s.StatementEntries = entries
+	s.statementVersion++
+	s.NotifyChange()
+}
+
 // DiscardStatementEntry discards a statement entry by index
 func (s *State) DiscardStatementEntry(i int) {
 	if i < 0 || i >= len(s.StatementEntries) {
 		return
 	}
-	s.StatementEntries = append(s.StatementEntries[:i], s.StatementEntries[i+1:]...)
+	s.discardedStatementEntries = append(s.discardedStatementEntries, s.StatementEntries[i])
+	s.StatementEntries = append(s.StatementEntries[:i:i], s.StatementEntries[i+1:]...)
+	// Keeps the same current entry, or the first one if it was discarded.
+	switch current := s.currentStatementIndex; {
+	case current == i:
+		s.currentStatementIndex = 0
+	case current > i:
+		s.currentStatementIndex--
+	}
+	s.statementVersion++
 	s.NotifyChange()
 }
 
+// DiscardDuplicatedStatementEntries discards all statement entries marked as
+// duplicates. The current entry stays the current one if it is not a
+// duplicate, otherwise the first entry becomes the current one.
+func (s *State) DiscardDuplicatedStatementEntries() {
+	entries := []finance.StatementEntry{}
+	current := 0
+	for i, entry := range s.StatementEntries {
+		if entry.Duplicate {
+			s.discardedStatementEntries = append(s.discardedStatementEntries, entry)
+			continue
+		}
+		if i == s.currentStatementIndex {
+			current = len(entries)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != len(s.StatementEntries) {
+		s.StatementEntries = entries
+		s.currentStatementIndex = current
+		s.statementVersion++
+		s.NotifyChange()
+	}
+}
+
 // NewDisplay returns a new Display
 func NewDisplay() *Display {
 	display := &Display{
 		IReact:             react.New(),
 		StatementModal:     NewStatementModal(),
+		PresetBuilder:      NewPresetBuilder(),
 		shortcutModal:      false,
 		loadStatementModal: false,
 		userMessage:        "",
 	}
 	display.StatementModal.AddOnChangeHook(display.NotifyChange)
+	display.PresetBuilder.AddOnChangeHook(display.NotifyChange)
 	return display
 }
 
@@ -575,10 +951,29 @@ func (d *Display) UserMessage() string {
 	return d.userMessage
 }
 
+func NewPresetBuilder() *PresetBuilder {
+	return &PresetBuilder{IReact: react.New()}
+}
+func (pb *PresetBuilder) Visible() bool { return pb.visible }
+func (pb *PresetBuilder) SetVisible(b bool) {
+	pb.visible = b
+	pb.NotifyChange()
+}
+
+// Preview returns the preview of the preset being built.
+func (pb *PresetBuilder) Preview() PresetPreview { return pb.preview }
+
+// SetPreview sets the preview of the preset being built.
+func (pb *PresetBuilder) SetPreview(x PresetPreview) {
+	pb.preview = x
+	pb.NotifyChange()
+}
+
 func NewStatementModal() *StatementModal {
 	return &StatementModal{
-		IReact:  react.New(),
-		visible: false,
+		IReact:       react.New(),
+		visible:      false,
+		editingEntry: -1,
 	}
 }
 func (sm *StatementModal) Visible() bool { return sm.visible }
@@ -587,6 +982,25 @@ func (sm *StatementModal) SetVisible(b bool) {
 	sm.NotifyChange()
 }
 func (sm *StatementModal) DefaultCsvFile() string { return sm.defaultCsvFile }
+
+// EditingEntry returns the index of the statement entry being edited, if any.
+func (sm *StatementModal) EditingEntry() (int, bool) {
+	return sm.editingEntry, sm.editingEntry != -1
+}
+
+// SetEditingEntry sets the index of the statement entry being edited.
+func (sm *StatementModal) SetEditingEntry(i int) {
+	sm.editingEntry = i
+	sm.NotifyChange()
+}
+
+// ClearEditingEntry stops editing a statement entry.
+func (sm *StatementModal) ClearEditingEntry() {
+	if sm.editingEntry != -1 {
+		sm.editingEntry = -1
+		sm.NotifyChange()
+	}
+}
 func (sm *StatementModal) SetDefaultCsvFile(x string) {
 	sm.defaultCsvFile = x
 	sm.NotifyChange()
@@ -265,6 +265,18 @@ func TestState(t *testing.T) {
 				assert.Equal(t, 2, c.hookCallCounter)
 			},
 		},
+		{
+			name: "Manipulates DateInterpretation",
+			run: func(t *testing.T, c *testcontext) {
+				assert.Equal(t, "", c.state.InputMetadata.DateInterpretation())
+				c.state.InputMetadata.SetDateInterpretation("yesterday")
+				c.state.InputMetadata.SetDateInterpretation("yesterday")
+				assert.Equal(t, 1, c.hookCallCounter)
+				assert.Equal(t, "yesterday", c.state.InputMetadata.DateInterpretation())
+				c.state.InputMetadata.Reset()
+				assert.Equal(t, "", c.state.InputMetadata.DateInterpretation())
+			},
+		},
 		{
 			name: "Manipulates selected tag",
 			run: func(t *testing.T, c *testcontext) {
@@ -295,6 +307,251 @@ func TestState(t *testing.T) {
 				assert.Empty(t, c.state.GetStatementEntries())
 			},
 		},
+		{
+			name: "Tracks statement progress",
+			run: func(t *testing.T, c *testcontext) {
+				stmEntries := []finance.StatementEntry{
+					{Description: "FOO"},
+					{Description: "BAR", Duplicate: true},
+					{Description: "BAZ"},
+				}
+				c.state.LoadStatement(StatementSource{Name: "file", Key: "key"}, stmEntries)
+				assert.Equal(t, []StatementSource{{Name: "file", Key: "key"}}, c.state.StatementSources())
+				version := c.state.StatementVersion()
+				c.state.DiscardStatementEntry(2)
+				c.state.DiscardDuplicatedStatementEntries()
+				c.state.PopStatementEntry()
+				assert.Equal(t, version+3, c.state.StatementVersion())
+				assert.Equal(t, StatementProgress{
+					Remaining: []finance.StatementEntry{},
+					Discarded: []finance.StatementEntry{
+						{Description: "BAZ", Source: "file"},
+						{Description: "BAR", Duplicate: true, Source: "file"},
+					},
+				}, c.state.StatementProgress("file"))
+			},
+		},
+		{
+			name: "Merges statements from different sources",
+			run: func(t *testing.T, c *testcontext) {
+				day := func(d int) time.Time { return time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC) }
+				c.state.LoadStatement(StatementSource{Name: "checking", Key: "k1"}, []finance.StatementEntry{
+					{Description: "C3", Date: day(3)},
+					{Description: "C1", Date: day(1)},
+				})
+				assert.Equal(t, []string{"C3", "C1"}, statementDescriptions(c.state.StatementEntries))
+				c.state.LoadStatement(StatementSource{Name: "card", Key: "k2"}, []finance.StatementEntry{
+					{Description: "K2", Date: day(2)},
+				})
+				assert.Equal(t, []string{"C1", "K2", "C3"}, statementDescriptions(c.state.StatementEntries))
+				assert.Equal(t, "card", c.state.StatementEntries[1].Source)
+				c.state.LoadStatement(StatementSource{Name: "checking", Key: "k3"}, []finance.StatementEntry{
+					{Description: "C4", Date: day(4)},
+				})
+				assert.Equal(t, []string{"K2", "C4"}, statementDescriptions(c.state.StatementEntries))
+				assert.Equal(t, []StatementSource{{Name: "card", Key: "k2"}, {Name: "checking", Key: "k3"}}, c.state.StatementSources())
+			},
+		},
+		{
+			name: "Unloads statement",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.LoadStatement(StatementSource{Name: "checking", Key: "k1"}, []finance.StatementEntry{
+					{Description: "C1"},
+					{Description: "C2"},
+				})
+				c.state.LoadStatement(StatementSource{Name: "card", Key: "k2"}, []finance.StatementEntry{
+					{Description: "K1"},
+				})
+				c.state.DiscardStatementEntry(0)
+				c.state.SetResumableStatementProgress("checking", StatementProgress{})
+				c.state.UnloadStatement("checking")
+				assert.Equal(t, []string{"K1"}, statementDescriptions(c.state.StatementEntries))
+				assert.Equal(t, []StatementSource{{Name: "card", Key: "k2"}}, c.state.StatementSources())
+				assert.Empty(t, c.state.StatementProgress("checking").Discarded)
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Resumes statement progress",
+			run: func(t *testing.T, c *testcontext) {
+				progress := StatementProgress{
+					Remaining: []finance.StatementEntry{{Description: "BAR"}},
+					Discarded: []finance.StatementEntry{{Description: "FOO"}},
+				}
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+				c.state.LoadStatement(StatementSource{Name: "other", Key: "k"}, []finance.StatementEntry{{Description: "BAZ"}})
+				c.state.SetResumableStatementProgress("file", progress)
+				c.state.LoadStatement(StatementSource{Name: "file", Key: "key"}, []finance.StatementEntry{{Description: "FOO"}, {Description: "BAR"}})
+				resumable, found := c.state.ResumableStatementProgress()
+				assert.True(t, found)
+				assert.Equal(t, progress, resumable)
+				c.state.ResumeStatementProgress()
+				_, found = c.state.ResumableStatementProgress()
+				assert.False(t, found)
+				assert.Equal(t, StatementProgress{
+					Remaining: []finance.StatementEntry{{Description: "BAR", Source: "file"}},
+					Discarded: []finance.StatementEntry{{Description: "FOO", Source: "file"}},
+				}, c.state.StatementProgress("file"))
+				assert.Equal(t, []string{"BAZ", "BAR"}, statementDescriptions(c.state.StatementEntries))
+			},
+		},
+		{
+			name: "Clears resumable statement progress",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetResumableStatementProgress("file", StatementProgress{})
+				assert.Equal(t, 1, c.hookCallCounter)
+				c.state.ClearResumableStatementProgress()
+				assert.Equal(t, 2, c.hookCallCounter)
+				c.state.ClearResumableStatementProgress()
+				assert.Equal(t, 2, c.hookCallCounter)
+				_, found := c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Clears resumable statement progress from a source",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetResumableStatementProgress("file", StatementProgress{})
+				c.state.ClearResumableStatementProgressFrom("other")
+				_, found := c.state.ResumableStatementProgress()
+				assert.True(t, found)
+				c.state.ClearResumableStatementProgressFrom("file")
+				_, found = c.state.ResumableStatementProgress()
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Selects the current statement entry",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					{Description: "FOO"},
+					{Description: "BAR"},
+					{Description: "BAZ"},
+					{Description: "QUX"},
+				})
+				current, _ := c.state.CurrentStatementEntry()
+				assert.Equal(t, "FOO", current.Description)
+				c.state.SetCurrentStatementEntry(4)
+				assert.Equal(t, 0, c.state.CurrentStatementIndex())
+				c.state.SetCurrentStatementEntry(2)
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "BAZ", current.Description)
+
+				// Discarding an entry before the current one keeps it
+				c.state.DiscardStatementEntry(0)
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "BAZ", current.Description)
+
+				// Popping removes the current entry
+				c.state.PopStatementEntry()
+				assert.Equal(t, []string{"BAR", "QUX"}, statementDescriptions(c.state.StatementEntries))
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "BAR", current.Description)
+
+				// Discarding the current entry makes the first one current
+				c.state.SetCurrentStatementEntry(1)
+				c.state.DiscardStatementEntry(1)
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "BAR", current.Description)
+			},
+		},
+		{
+			name: "Keeps the current statement entry when loading and unloading sources",
+			run: func(t *testing.T, c *testcontext) {
+				day := func(d int) time.Time { return time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC) }
+				c.state.LoadStatement(StatementSource{Name: "checking", Key: "k1"}, []finance.StatementEntry{
+					{Description: "C1", Date: day(1)},
+					{Description: "C3", Date: day(3)},
+				})
+				c.state.SetCurrentStatementEntry(1)
+
+				// Loading another source re-sorts the entries
+				c.state.LoadStatement(StatementSource{Name: "card", Key: "k2"}, []finance.StatementEntry{
+					{Description: "K2", Date: day(2)},
+				})
+				assert.Equal(t, []string{"C1", "K2", "C3"}, statementDescriptions(c.state.StatementEntries))
+				current, _ := c.state.CurrentStatementEntry()
+				assert.Equal(t, "C3", current.Description)
+
+				// Resuming the progress of the other source
+				c.state.SetResumableStatementProgress("card", StatementProgress{
+					Remaining: []finance.StatementEntry{{Description: "K4", Date: day(4)}},
+				})
+				c.state.ResumeStatementProgress()
+				assert.Equal(t, []string{"C1", "C3", "K4"}, statementDescriptions(c.state.StatementEntries))
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "C3", current.Description)
+
+				// Unloading the other source
+				c.state.UnloadStatement("card")
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "C3", current.Description)
+
+				// Unloading the source of the current entry falls back to the first
+				c.state.LoadStatement(StatementSource{Name: "card", Key: "k2"}, []finance.StatementEntry{
+					{Description: "K2", Date: day(2)},
+					{Description: "K5", Date: day(5)},
+				})
+				c.state.UnloadStatement("checking")
+				current, _ = c.state.CurrentStatementEntry()
+				assert.Equal(t, "K2", current.Description)
+				assert.Equal(t, 0, c.state.CurrentStatementIndex())
+			},
+		},
+		{
+			name: "Updates statement entry",
+			run: func(t *testing.T, c *testcontext) {
+				stmEntries := []finance.StatementEntry{{Description: "FOO"}, {Description: "BAR"}}
+				c.state.SetStatementEntries(stmEntries)
+				version := c.state.StatementVersion()
+				c.state.UpdateStatementEntry(1, finance.StatementEntry{Description: "BAZ"})
+				c.state.UpdateStatementEntry(2, finance.StatementEntry{Description: "IGNORED"})
+				assert.Equal(t, []string{"FOO", "BAZ"}, statementDescriptions(c.state.StatementEntries))
+				assert.Equal(t, "BAR", stmEntries[1].Description)
+				assert.Equal(t, version+1, c.state.StatementVersion())
+			},
+		},
+		{
+			name: "Discards duplicated statement entries",
+			run: func(t *testing.T, c *testcontext) {
+				stmEntries := []finance.StatementEntry{
+					{Description: "FOO", Duplicate: true},
+					{Description: "BAR"},
+				}
+				c.state.SetStatementEntries(stmEntries)
+				assert.Equal(t, 1, c.hookCallCounter)
+				c.state.DiscardDuplicatedStatementEntries()
+				assert.Equal(t, 2, c.hookCallCounter)
+				assert.Equal(t, []finance.StatementEntry{{Description: "BAR"}}, c.state.GetStatementEntries())
+				c.state.DiscardDuplicatedStatementEntries()
+				assert.Equal(t, 2, c.hookCallCounter)
+			},
+		},
+		{
+			name: "Discarding duplicated statement entries keeps the current entry",
+			run: func(t *testing.T, c *testcontext) {
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					{Description: "FOO", Duplicate: true},
+					{Description: "BAR"},
+					{Description: "BAZ", Duplicate: true},
+					{Description: "QUX"},
+				})
+				c.state.SetCurrentStatementEntry(3)
+				c.state.DiscardDuplicatedStatementEntries()
+				current, _ := c.state.CurrentStatementEntry()
+				assert.Equal(t, "QUX", current.Description)
+
+				c.state.SetStatementEntries([]finance.StatementEntry{
+					{Description: "FOO"},
+					{Description: "BAR", Duplicate: true},
+				})
+				c.state.SetCurrentStatementEntry(1)
+				c.state.DiscardDuplicatedStatementEntries()
+				assert.Equal(t, 0, c.state.CurrentStatementIndex())
+			},
+		},
 		{
 			name: "InputMetadata resets properly",
 			run: func(t *testing.T, c *testcontext) {
@@ -374,6 +631,38 @@ func TestJournalMetadata(t *testing.T) {
 				assert.Equal(t, 1, c.hookCallCounter)
 			},
 		},
+		{
+			name: "Manipulate default commodity",
+			run: func(t *testing.T, c *testcontext) {
+				assert.Equal(t, "", c.journalMetadata.DefaultCommodity())
+				c.journalMetadata.SetDefaultCommodity("BRL")
+				assert.Equal(t, "BRL", c.journalMetadata.DefaultCommodity())
+				assert.Equal(t, 1, c.hookCallCounter)
+			},
+		},
+		{
+			name: "Account commodity",
+			run: func(t *testing.T, c *testcontext) {
+				posting := func(account, commodity string) journal.Posting {
+					return journal.Posting{Account: account, Ammount: finance.Ammount{Commodity: commodity}}
+				}
+				_, found := c.journalMetadata.AccountCommodity("assets:bank")
+				assert.False(t, found)
+				c.journalMetadata.SetTransactions([]journal.Transaction{
+					{Posting: []journal.Posting{posting("assets:bank", "BRL"), posting("expenses:food", "BRL")}},
+					{Posting: []journal.Posting{posting("assets:bank", "USD"), posting("expenses:food", "USD")}},
+				})
+				commodity, found := c.journalMetadata.AccountCommodity("assets:bank")
+				assert.True(t, found)
+				assert.Equal(t, "BRL", commodity)
+				c.journalMetadata.AppendTransaction(journal.Transaction{
+					Posting: []journal.Posting{posting("assets:bank", "USD"), posting("expenses:food", "USD")},
+				})
+				commodity, found = c.journalMetadata.AccountCommodity("assets:bank")
+				assert.True(t, found)
+				assert.Equal(t, "USD", commodity)
+			},
+		},
 		{
 			name: "Remove duplicat tags",
 			run: func(t *testing.T, c *testcontext) {
@@ -430,6 +719,13 @@ func TestDisplay(t *testing.T) {
 					assert.Equal(t, 1, c.hookCallCounter)
 				},
 			},
+			{
+				name: "Notifies for updates to PresetBuilder",
+				run: func(t *testing.T, c *testcontext) {
+					c.display.PresetBuilder.SetVisible(true)
+					assert.Equal(t, 1, c.hookCallCounter)
+				},
+			},
 		}
 
 		for _, tc := range testcases {
@@ -453,4 +749,37 @@ func TestStatementModal(t *testing.T) {
 	state.SetVisible(true)
 	assert.True(t, state.Visible())
 	assert.Equal(t, 1, callCounter)
+	_, editing := state.EditingEntry()
+	assert.False(t, editing)
+	state.SetEditingEntry(2)
+	index, editing := state.EditingEntry()
+	assert.True(t, editing)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, 2, callCounter)
+	state.ClearEditingEntry()
+	_, editing = state.EditingEntry()
+	assert.False(t, editing)
+	assert.Equal(t, 3, callCounter)
+}
+
+func TestPresetBuilder(t *testing.T) {
+	state := NewPresetBuilder()
+	callCounter := 0
+	state.AddOnChangeHook(func() { callCounter += 1 })
+	assert.False(t, state.Visible())
+	state.SetVisible(true)
+	assert.True(t, state.Visible())
+	assert.Equal(t, 1, callCounter)
+	preview := PresetPreview{Rows: [][]string{{"a", "b"}}, Err: assert.AnError}
+	state.SetPreview(preview)
+	assert.Equal(t, preview, state.Preview())
+	assert.Equal(t, 2, callCounter)
+}
+
+func statementDescriptions(entries []finance.StatementEntry) []string {
+	descriptions := []string{}
+	for _, entry := range entries {
+		descriptions = append(descriptions, entry.Description)
+	}
+	return descriptions
 }
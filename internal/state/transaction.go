@@ -28,6 +28,7 @@ type TransactionData struct {
 	Date        MaybeValue[time.Time]
 	Description MaybeValue[string]
 	Tags        ArrayValue[journal.Tag]
+	Comment     MaybeValue[string]
 	Postings    ArrayValue[*PostingData]
 }
 
@@ -36,6 +37,7 @@ func NewTransactionData() *TransactionData {
 	out.Date.AddOnChangeHook(out.NotifyChange)
 	out.Description.AddOnChangeHook(out.NotifyChange)
 	out.Tags.AddOnChangeHook(out.NotifyChange)
+	out.Comment.AddOnChangeHook(out.NotifyChange)
 	out.Postings.AddOnChangeHook(out.NotifyChange)
 	return out
 }
@@ -0,0 +1,88 @@
+// statementprogress persists the progress of the user on a statement, so it
+// can be resumed in a later session.
+package statementprogress
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	statemod "github.com/vitorqb/addledger/internal/state"
+)
+
+//go:generate $MOCKGEN --source=statementprogress.go --destination=../../mocks/statementprogress/statementprogress_mock.go
+
+// IStore saves and loads statement progress by key.
+type IStore interface {
+	// Load loads the progress saved for a key. The second returned value is
+	// false if there is no progress saved for the key.
+	Load(key string) (statemod.StatementProgress, bool, error)
+	// Save saves the progress for a key.
+	Save(key string, progress statemod.StatementProgress) error
+}
+
+// Store implements IStore saving each progress as a json file in a directory.
+type Store struct {
+	dir string
+}
+
+var _ IStore = &Store{}
+
+// Load implements IStore.
+func (s *Store) Load(key string) (statemod.StatementProgress, bool, error) {
+	content, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return statemod.StatementProgress{}, false, nil
+	}
+	if err != nil {
+		return statemod.StatementProgress{}, false, fmt.Errorf("failed to read statement progress: %w", err)
+	}
+	var progress statemod.StatementProgress
+	if err := json.Unmarshal(content, &progress); err != nil {
+		return statemod.StatementProgress{}, false, fmt.Errorf("failed to parse statement progress: %w", err)
+	}
+	return progress, true, nil
+}
+
+// Save implements IStore.
+func (s *Store) Save(key string, progress statemod.StatementProgress) error {
+	content, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to serialize statement progress: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create statement progress dir: %w", err)
+	}
+	if err := os.WriteFile(s.path(key), content, 0600); err != nil {
+		return fmt.Errorf("failed to write statement progress: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+// New returns a new Store saving files into `dir`.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the default directory for saving statement progress.
+func DefaultDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find cache dir: %w", err)
+	}
+	return filepath.Join(cacheDir, "addledger", "statements"), nil
+}
+
+// Key returns the key for a statement file content.
+func Key(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,76 @@
+package statementprogress_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vitorqb/addledger/internal/finance"
+	statemod "github.com/vitorqb/addledger/internal/state"
+	. "github.com/vitorqb/addledger/internal/statementprogress"
+)
+
+func TestStore(t *testing.T) {
+	type testcontext struct {
+		dir   string
+		store *Store
+	}
+	type testcase struct {
+		name string
+		run  func(t *testing.T, c *testcontext)
+	}
+	testcases := []testcase{
+		{
+			name: "Load not found",
+			run: func(t *testing.T, c *testcontext) {
+				_, found, err := c.store.Load("foo")
+				assert.NoError(t, err)
+				assert.False(t, found)
+			},
+		},
+		{
+			name: "Save and load",
+			run: func(t *testing.T, c *testcontext) {
+				progress := statemod.StatementProgress{
+					Remaining: []finance.StatementEntry{{Description: "FOO"}},
+					Discarded: []finance.StatementEntry{{Description: "BAR"}},
+				}
+				err := c.store.Save("foo", progress)
+				assert.NoError(t, err)
+				loaded, found, err := c.store.Load("foo")
+				assert.NoError(t, err)
+				assert.True(t, found)
+				assert.Equal(t, "FOO", loaded.Remaining[0].Description)
+				assert.Equal(t, "BAR", loaded.Discarded[0].Description)
+				_, err = os.Stat(filepath.Join(c.dir, "foo.json"))
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "Load invalid file",
+			run: func(t *testing.T, c *testcontext) {
+				err := os.MkdirAll(c.dir, 0700)
+				assert.NoError(t, err)
+				err = os.WriteFile(filepath.Join(c.dir, "foo.json"), []byte("{"), 0600)
+				assert.NoError(t, err)
+				_, _, err = c.store.Load("foo")
+				assert.ErrorContains(t, err, "failed to parse statement progress")
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := new(testcontext)
+			c.dir = filepath.Join(t.TempDir(), "statements")
+			c.store = New(c.dir)
+			tc.run(t, c)
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key([]byte("foo")), Key([]byte("foo")))
+	assert.NotEqual(t, Key([]byte("foo")), Key([]byte("bar")))
+	assert.Len(t, Key([]byte("foo")), 64)
+}
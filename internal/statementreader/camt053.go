@@ -0,0 +1,190 @@
+package statementreader
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/vitorqb/addledger/internal/finance"
+)
+
+// camtDocument is the subset of a CAMT.053 document we care about. Namespaces
+// are ignored, so any camt.053.001.XX version is accepted.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Ammount              camtAmmount           `xml:"Amt"`
+	CreditDebitIndicator string                `xml:"CdtDbtInd"`
+	BookingDate          camtDate              `xml:"BookgDt"`
+	ValueDate            camtDate              `xml:"ValDt"`
+	AdditionalInfo       string                `xml:"AddtlNtryInf"`
+	TransactionDetails   []camtTransactionInfo `xml:"NtryDtls>TxDtls"`
+}
+
+type camtAmmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtTransactionInfo struct {
+	Debtor     camtParty      `xml:"RltdPties>Dbtr"`
+	Creditor   camtParty      `xml:"RltdPties>Cdtr"`
+	Remittance camtRemittance `xml:"RmtInf"`
+}
+
+// camtParty supports both the old (`Dbtr>Nm`) and the new (`Dbtr>Pty>Nm`)
+// ways of naming a party.
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+type camtRemittance struct {
+	Unstructured []string `xml:"Ustrd"`
+	Reference    string   `xml:"Strd>CdtrRefInf>Ref"`
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return strings.TrimSpace(p.Name)
+	}
+	return strings.TrimSpace(p.PartyName)
+}
+
+func (r camtRemittance) text() string {
+	if len(r.Unstructured) > 0 {
+		parts := make([]string, 0, len(r.Unstructured))
+		for _, x := range r.Unstructured {
+			if x = strings.TrimSpace(x); x != "" {
+				parts = append(parts, x)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return strings.TrimSpace(r.Reference)
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	value := strings.TrimSpace(d.Date)
+	if value == "" {
+		value = strings.TrimSpace(d.DateTime)
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	// Date times are truncated to the date.
+	if len(value) > 10 {
+		value = value[:10]
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// readCAMT053 reads the entries of a CAMT.053 statement. Like in the other
+// formats, money going out is positive: debits are read as positive ammounts
+// and credits as negative ones. The description is built from the
+// counterparty name and the remittance information.
+func readCAMT053(reader io.Reader, config Config) ([]finance.StatementEntry, error) {
+	var document camtDocument
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = camtCharsetReader(config.Encoding)
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error reading camt053 file: %w", err)
+	}
+	var statementEntries []finance.StatementEntry
+	for _, statement := range document.Statements {
+		for _, entry := range statement.Entries {
+			statementEntry, err := parseCAMTEntry(entry)
+			if err != nil {
+				return nil, err
+			}
+			statementEntries = append(statementEntries, statementEntry)
+		}
+	}
+	return statementEntries, nil
+}
+
+// camtCharsetReader decodes the encoding declared by the xml file. If the
+// statement encoding was given, the file was already converted to UTF-8.
+func camtCharsetReader(statementEncoding Encoding) func(string, io.Reader) (io.Reader, error) {
+	return func(label string, input io.Reader) (io.Reader, error) {
+		if statementEncoding != EncodingDefault {
+			return input, nil
+		}
+		encoding, err := ParseEncoding(label)
+		if err != nil {
+			return nil, err
+		}
+		return NewDecodingReader(input, encoding), nil
+	}
+}
+
+func parseCAMTEntry(entry camtEntry) (finance.StatementEntry, error) {
+	quantity, err := decimal.NewFromString(strings.TrimSpace(entry.Ammount.Value))
+	if err != nil {
+		return finance.StatementEntry{}, fmt.Errorf("invalid amount format: %s", entry.Ammount.Value)
+	}
+	switch indicator := strings.TrimSpace(entry.CreditDebitIndicator); indicator {
+	case "CRDT":
+		quantity = quantity.Neg()
+	case "DBIT":
+	default:
+		return finance.StatementEntry{}, fmt.Errorf("invalid credit/debit indicator: %s", indicator)
+	}
+	bookingDate, err := entry.BookingDate.parse()
+	if err != nil {
+		return finance.StatementEntry{}, fmt.Errorf("invalid booking date: %w", err)
+	}
+	valueDate, err := entry.ValueDate.parse()
+	if err != nil {
+		return finance.StatementEntry{}, fmt.Errorf("invalid value date: %w", err)
+	}
+	if bookingDate.IsZero() {
+		bookingDate = valueDate
+	}
+	return finance.StatementEntry{
+		Date:        bookingDate,
+		ValueDate:   valueDate,
+		Description: camtDescription(entry),
+		Ammount: finance.Ammount{
+			Commodity: strings.TrimSpace(entry.Ammount.Currency),
+			Quantity:  quantity,
+		},
+	}, nil
+}
+
+// camtDescription joins the counterparty and the remittance info. For debits
+// the counterparty is the creditor, for credits it's the debtor.
+func camtDescription(entry camtEntry) string {
+	parts := []string{}
+	if len(entry.TransactionDetails) > 0 {
+		details := entry.TransactionDetails[0]
+		counterparty := details.Debtor
+		if strings.TrimSpace(entry.CreditDebitIndicator) == "DBIT" {
+			counterparty = details.Creditor
+		}
+		if name := counterparty.name(); name != "" {
+			parts = append(parts, name)
+		}
+		if remittance := details.Remittance.text(); remittance != "" {
+			parts = append(parts, remittance)
+		}
+	}
+	if len(parts) == 0 {
+		return strings.TrimSpace(entry.AdditionalInfo)
+	}
+	return strings.Join(parts, " - ")
+}
@@ -0,0 +1,177 @@
+package statementreader_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vitorqb/addledger/internal/finance"
+	. "github.com/vitorqb/addledger/internal/statementreader"
+)
+
+const camt053Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="EUR">53.73</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><Dt>2023-09-27</Dt></BookgDt>
+        <ValDt><Dt>2023-09-26</Dt></ValDt>
+        <NtryDtls>
+          <TxDtls>
+            <RltdPties>
+              <Dbtr><Nm>John Doe</Nm></Dbtr>
+              <Cdtr><Nm>SUPERMARKET</Nm></Cdtr>
+            </RltdPties>
+            <RmtInf><Ustrd>Card 1234</Ustrd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">1000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><DtTm>2023-09-28T10:00:00</DtTm></BookgDt>
+        <ValDt><Dt>2023-09-28</Dt></ValDt>
+        <NtryDtls>
+          <TxDtls>
+            <RltdPties>
+              <Dbtr><Pty><Nm>ACME</Nm></Pty></Dbtr>
+            </RltdPties>
+            <RmtInf><Strd><CdtrRefInf><Ref>SALARY</Ref></CdtrRefInf></Strd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="USD">8</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <ValDt><Dt>2023-09-29</Dt></ValDt>
+        <AddtlNtryInf>BANK FEE</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+// latin1CAMT053Statement is a CAMT.053 statement encoded as ISO-8859-1.
+var latin1CAMT053Statement = `<?xml version="1.0" encoding="ISO-8859-1"?>
+<Document><BkToCstmrStmt><Stmt><Ntry>
+  <Amt Ccy="EUR">3</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+  <BookgDt><Dt>2023-09-27</Dt></BookgDt>
+  <AddtlNtryInf>CAF` + "\xc9" + `</AddtlNtryInf>
+</Ntry></Stmt></BkToCstmrStmt></Document>`
+
+func TestCAMT053Reader(t *testing.T) {
+	type testCase struct {
+		name          string
+		options       []Option
+		input         string
+		expected      []finance.StatementEntry
+		expectedError string
+	}
+	testCases := []testCase{
+		{
+			name: "Reads entries",
+			options: []Option{
+				WithFormat(FormatCAMT053),
+				WithAccountName("ACC"),
+			},
+			input: camt053Statement,
+			expected: []finance.StatementEntry{
+				{
+					Account:     "ACC",
+					Date:        time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC),
+					ValueDate:   time.Date(2023, 9, 26, 0, 0, 0, 0, time.UTC),
+					Description: "SUPERMARKET - Card 1234",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(5373, -2)},
+				},
+				{
+					Account:     "ACC",
+					Date:        time.Date(2023, 9, 28, 0, 0, 0, 0, time.UTC),
+					ValueDate:   time.Date(2023, 9, 28, 0, 0, 0, 0, time.UTC),
+					Description: "ACME - SALARY",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(-100000, -2)},
+				},
+				{
+					Account:     "ACC",
+					Date:        time.Date(2023, 9, 29, 0, 0, 0, 0, time.UTC),
+					ValueDate:   time.Date(2023, 9, 29, 0, 0, 0, 0, time.UTC),
+					Description: "BANK FEE",
+					Ammount:     finance.Ammount{Commodity: "USD", Quantity: decimal.New(8, 0)},
+				},
+			},
+		},
+		{
+			name:    "Reads files with other encodings",
+			options: []Option{WithFormat(FormatCAMT053)},
+			input:   latin1CAMT053Statement,
+			expected: []finance.StatementEntry{
+				{
+					Date:        time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC),
+					Description: "CAFÉ",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(3, 0)},
+				},
+			},
+		},
+		{
+			name:    "Reads files already decoded with the statement encoding",
+			options: []Option{WithFormat(FormatCAMT053), WithEncoding(EncodingISO88591)},
+			input:   latin1CAMT053Statement,
+			expected: []finance.StatementEntry{
+				{
+					Date:        time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC),
+					Description: "CAFÉ",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(3, 0)},
+				},
+			},
+		},
+		{
+			name:          "Invalid xml",
+			options:       []Option{WithFormat(FormatCAMT053)},
+			input:         `<Document>`,
+			expectedError: "error reading camt053 file",
+		},
+		{
+			name:    "Invalid indicator",
+			options: []Option{WithFormat(FormatCAMT053)},
+			input: `<Document><BkToCstmrStmt><Stmt><Ntry>
+				<Amt Ccy="EUR">1</Amt><CdtDbtInd>FOO</CdtDbtInd>
+			</Ntry></Stmt></BkToCstmrStmt></Document>`,
+			expectedError: "invalid credit/debit indicator: FOO",
+		},
+		{
+			name:    "Invalid amount",
+			options: []Option{WithFormat(FormatCAMT053)},
+			input: `<Document><BkToCstmrStmt><Stmt><Ntry>
+				<Amt Ccy="EUR">abc</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+			</Ntry></Stmt></BkToCstmrStmt></Document>`,
+			expectedError: "invalid amount format: abc",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := NewStatementReader().Read(strings.NewReader(tc.input), tc.options...)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, entries)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = ParseFormat("CAMT053")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCAMT053, format)
+
+	_, err = ParseFormat("foo")
+	assert.ErrorContains(t, err, "invalid statement format: foo")
+}
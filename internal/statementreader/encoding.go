@@ -0,0 +1,70 @@
+package statementreader
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Encoding represents the character encoding of a statement file.
+type Encoding string
+
+const (
+	// EncodingDefault reads the file as it is, unless it starts with a BOM.
+	EncodingDefault     Encoding = ""
+	EncodingUTF8        Encoding = "utf-8"
+	EncodingUTF16LE     Encoding = "utf-16le"
+	EncodingUTF16BE     Encoding = "utf-16be"
+	EncodingISO88591    Encoding = "iso-8859-1"
+	EncodingWindows1252 Encoding = "windows-1252"
+)
+
+// encodingAliases are other common names for the encodings.
+var encodingAliases = map[string]Encoding{
+	"utf8":    EncodingUTF8,
+	"utf16le": EncodingUTF16LE,
+	"utf16be": EncodingUTF16BE,
+	"latin1":  EncodingISO88591,
+	"latin-1": EncodingISO88591,
+	"cp1252":  EncodingWindows1252,
+}
+
+// ParseEncoding parses an Encoding from a string (case insensitive).
+func ParseEncoding(x string) (Encoding, error) {
+	name := strings.ToLower(strings.TrimSpace(x))
+	if encoding, found := encodingAliases[name]; found {
+		return encoding, nil
+	}
+	switch encoding := Encoding(name); encoding {
+	case EncodingDefault, EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE, EncodingISO88591, EncodingWindows1252:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("invalid statement encoding: %s", x)
+	}
+}
+
+// NewDecodingReader returns a reader that converts a file with the given
+// encoding to UTF-8. A BOM at the start of the file (UTF-8 or UTF-16)
+// overrides the encoding and is removed.
+func NewDecodingReader(reader io.Reader, encoding Encoding) io.Reader {
+	var decoder transform.Transformer
+	switch encoding {
+	case EncodingUTF8:
+		decoder = unicode.UTF8.NewDecoder()
+	case EncodingUTF16LE:
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder()
+	case EncodingUTF16BE:
+		decoder = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder()
+	case EncodingISO88591:
+		decoder = charmap.ISO8859_1.NewDecoder()
+	case EncodingWindows1252:
+		decoder = charmap.Windows1252.NewDecoder()
+	default:
+		decoder = transform.Nop
+	}
+	return transform.NewReader(reader, unicode.BOMOverride(decoder))
+}
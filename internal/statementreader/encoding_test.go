@@ -0,0 +1,73 @@
+package statementreader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/statementreader"
+)
+
+func TestParseEncoding(t *testing.T) {
+	type testcase struct {
+		input         string
+		expected      Encoding
+		expectedError string
+	}
+	testcases := []testcase{
+		{input: "", expected: EncodingDefault},
+		{input: "UTF-8", expected: EncodingUTF8},
+		{input: "utf16le", expected: EncodingUTF16LE},
+		{input: "UTF-16BE", expected: EncodingUTF16BE},
+		{input: "latin1", expected: EncodingISO88591},
+		{input: "ISO-8859-1", expected: EncodingISO88591},
+		{input: "cp1252", expected: EncodingWindows1252},
+		{input: "Windows-1252", expected: EncodingWindows1252},
+		{input: "ebcdic", expectedError: "invalid statement encoding: ebcdic"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			encoding, err := ParseEncoding(tc.input)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, encoding)
+		})
+	}
+}
+
+func TestReadEncodedStatement(t *testing.T) {
+	type testcase struct {
+		name     string
+		encoding Encoding
+		input    string
+	}
+	testcases := []testcase{
+		{name: "Default", encoding: EncodingDefault, input: "CAFÉ,1"},
+		{name: "Default with UTF-8 BOM", encoding: EncodingDefault, input: "\xef\xbb\xbfCAFÉ,1"},
+		{name: "Default with UTF-16LE BOM", encoding: EncodingDefault, input: "\xff\xfeC\x00A\x00F\x00\xc9\x00,\x001\x00"},
+		{name: "UTF-16BE", encoding: EncodingUTF16BE, input: "\x00C\x00A\x00F\x00\xc9\x00,\x001"},
+		{name: "UTF-16LE", encoding: EncodingUTF16LE, input: "C\x00A\x00F\x00\xc9\x00,\x001\x00"},
+		{name: "ISO-8859-1", encoding: EncodingISO88591, input: "CAF\xc9,1"},
+		{name: "Windows-1252", encoding: EncodingWindows1252, input: "CAF\xc9,1"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := NewStatementReader()
+			entries, err := reader.Read(
+				strings.NewReader(tc.input),
+				WithEncoding(tc.encoding),
+				WithLoaderMapping([]CSVColumnMapping{
+					{Column: 0, Importer: DescriptionImporter{}},
+					{Column: 1, Importer: AmmountImporter{}},
+				}),
+			)
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1)
+			assert.Equal(t, "CAFÉ", entries[0].Description)
+			assert.Equal(t, "1", entries[0].Ammount.Quantity.String())
+		})
+	}
+}
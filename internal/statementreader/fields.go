@@ -2,6 +2,7 @@ package statementreader
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/vitorqb/addledger/internal/finance"
@@ -54,10 +55,14 @@ func (d DescriptionImporter) Import(statementEntry *finance.StatementEntry, valu
 var _ FieldImporter = DescriptionImporter{}
 
 // AmmountImporter imports the amount field.
-type AmmountImporter struct{}
+type AmmountImporter struct {
+	// DecimalComma is true if the ammount uses a comma as decimal mark (and
+	// dots as thousands separators), like `1.234,56`.
+	DecimalComma bool
+}
 
 func (a AmmountImporter) Import(statementEntry *finance.StatementEntry, value string) error {
-	if parsed, err := userinput.TextToAmmount(value); err == nil {
+	if parsed, err := userinput.TextToAmmount(normalizeDecimalMark(value, a.DecimalComma)); err == nil {
 		statementEntry.Ammount = parsed
 		return nil
 	}
@@ -65,3 +70,114 @@ func (a AmmountImporter) Import(statementEntry *finance.StatementEntry, value st
 }
 
 var _ FieldImporter = AmmountImporter{}
+
+// AmmountInImporter imports the amount field from a column with the money
+// coming in (e.g. hledger's `amount-in`). The ammount is imported as negative,
+// since money going out is positive. Empty or zero values are ignored, so it
+// can be combined with an AmmountOutImporter.
+type AmmountInImporter struct {
+	DecimalComma bool
+}
+
+func (a AmmountInImporter) Import(statementEntry *finance.StatementEntry, value string) error {
+	return importDirectionalAmmount(statementEntry, normalizeDecimalMark(value, a.DecimalComma), true)
+}
+
+var _ FieldImporter = AmmountInImporter{}
+
+// AmmountOutImporter imports the amount field from a column with the money
+// going out (e.g. hledger's `amount-out`). The ammount is imported as
+// positive. Empty or zero values are ignored.
+type AmmountOutImporter struct {
+	DecimalComma bool
+}
+
+func (a AmmountOutImporter) Import(statementEntry *finance.StatementEntry, value string) error {
+	return importDirectionalAmmount(statementEntry, normalizeDecimalMark(value, a.DecimalComma), false)
+}
+
+var _ FieldImporter = AmmountOutImporter{}
+
+// normalizeDecimalMark converts an ammount using a decimal comma (e.g.
+// `1.234,56`) to one using a decimal dot (e.g. `1234.56`).
+func normalizeDecimalMark(value string, decimalComma bool) string {
+	if !decimalComma {
+		return value
+	}
+	value = strings.ReplaceAll(value, ".", "")
+	return strings.ReplaceAll(value, ",", ".")
+}
+
+func importDirectionalAmmount(statementEntry *finance.StatementEntry, value string, negative bool) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	parsed, err := userinput.TextToAmmount(value)
+	if err != nil {
+		return fmt.Errorf("invalid amount format: %s", value)
+	}
+	if parsed.Quantity.IsZero() {
+		return nil
+	}
+	parsed.Quantity = parsed.Quantity.Abs()
+	if negative {
+		parsed.Quantity = parsed.Quantity.Neg()
+	}
+	statementEntry.Ammount = parsed
+	return nil
+}
+
+// CommodityImporter imports the commodity of the ammount, for statements with
+// one commodity per row. Empty values are ignored. It must be mapped after the
+// ammount, since the ammount importers replace the whole ammount.
+type CommodityImporter struct{}
+
+func (c CommodityImporter) Import(statementEntry *finance.StatementEntry, value string) error {
+	if value = strings.TrimSpace(value); value != "" {
+		statementEntry.Ammount.Commodity = value
+	}
+	return nil
+}
+
+var _ FieldImporter = CommodityImporter{}
+
+// InvertSignImporter inverts the sign of the ammount if the field is one of
+// `Values` (case insensitive), e.g. for a debit/credit indicator column. It
+// must be mapped after the ammount.
+type InvertSignImporter struct {
+	Values []string
+}
+
+func (i InvertSignImporter) Import(statementEntry *finance.StatementEntry, value string) error {
+	value = strings.TrimSpace(value)
+	for _, invertValue := range i.Values {
+		if strings.EqualFold(value, strings.TrimSpace(invertValue)) {
+			statementEntry.Ammount = statementEntry.Ammount.InvertSign()
+			return nil
+		}
+	}
+	return nil
+}
+
+var _ FieldImporter = InvertSignImporter{}
+
+// NamedFieldImporter imports an extra named field (see
+// `finance.StatementEntry.Fields`). Empty values are ignored.
+type NamedFieldImporter struct {
+	Name string
+}
+
+func (n NamedFieldImporter) Import(statementEntry *finance.StatementEntry, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if statementEntry.Fields == nil {
+		statementEntry.Fields = map[string]string{}
+	}
+	statementEntry.Fields[n.Name] = value
+	return nil
+}
+
+var _ FieldImporter = NamedFieldImporter{}
@@ -0,0 +1,394 @@
+package statementreader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// inferSampleRows is the maximum number of rows read when inferring how to
+// read a csv statement.
+const inferSampleRows = 50
+
+// inferMinMatches is the minimum fraction of the values of a column that
+// must look like a date (or ammount) for the column to be a date (or
+// ammount) column.
+const inferMinMatches = 0.8
+
+// inferSeparators are the csv separators tried, in order of preference.
+var inferSeparators = []rune{',', ';', '\t', '|'}
+
+// inferDateFormats are the date formats tried, in order of preference. When
+// the dates are valid in more than one format (e.g. 01/02/2023), the first
+// one wins.
+var inferDateFormats = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"01/02/2006",
+	"02.01.2006",
+	"02-01-2006",
+	"01-02-2006",
+	"2006/01/02",
+	"2/1/2006",
+	"1/2/2006",
+	"02/01/06",
+	"01/02/06",
+}
+
+var ammountRegex = regexp.MustCompile(`^[+-]?[A-Z$€£]{0,3} ?[+-]?[0-9][0-9.,']*$`)
+var decimalCommaRegex = regexp.MustCompile(`,[0-9]{1,2}$`)
+var decimalDotRegex = regexp.MustCompile(`\.[0-9]{1,2}$`)
+
+// Inference is a guess of how to read a csv statement.
+type Inference struct {
+	// Encoding is the character encoding of the file.
+	Encoding Encoding
+	// Separator is the csv separator.
+	Separator rune
+	// SkipRows is the number of rows to skip (1 if there is a header).
+	SkipRows int
+	// DateColumn is the index of the date column, or -1 if none.
+	DateColumn int
+	// DateFormat is the layout of the dates.
+	DateFormat string
+	// AmmountColumn is the index of the ammount column, or -1 if none.
+	AmmountColumn int
+	// DecimalComma is true if the ammounts use a comma as decimal mark.
+	DecimalComma bool
+	// DescriptionColumn is the index of the description column, or -1 if
+	// none.
+	DescriptionColumn int
+	// Report explains each guess.
+	Report []InferenceReport
+}
+
+// InferenceReport explains how a field was guessed.
+type InferenceReport struct {
+	// Field is the guessed field (e.g. `separator` or `date`).
+	Field string
+	// Confidence is a number between 0 (a wild guess) and 1 (certain).
+	Confidence float64
+	// Reason explains the guess to the user.
+	Reason string
+}
+
+// Confidence returns the lowest confidence of the guesses.
+func (i Inference) Confidence() float64 {
+	confidence := 1.0
+	for _, report := range i.Report {
+		if report.Confidence < confidence {
+			confidence = report.Confidence
+		}
+	}
+	return confidence
+}
+
+// String returns a report of the guesses for the user.
+func (i Inference) String() string {
+	lines := []string{}
+	for _, report := range i.Report {
+		lines = append(lines, fmt.Sprintf("%s (%.0f%%): %s", report.Field, report.Confidence*100, report.Reason))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (i *Inference) report(field string, confidence float64, reason string, args ...any) {
+	i.Report = append(i.Report, InferenceReport{field, confidence, fmt.Sprintf(reason, args...)})
+}
+
+// InferCSV guesses how to read a csv statement from a sample of it. It
+// sniffs the separator, detects a header row and looks for a date column,
+// an ammount column and a description column (the longest text column).
+// An error is returned if any of these columns is not found.
+func InferCSV(sample []byte) (Inference, error) {
+	inference := Inference{DateColumn: -1, AmmountColumn: -1, DescriptionColumn: -1}
+
+	// Files starting with a BOM are always decoded. Other files that are not
+	// valid UTF-8 are most likely from Windows.
+	decoded, err := io.ReadAll(NewDecodingReader(bytes.NewReader(sample), EncodingDefault))
+	if err != nil {
+		return inference, fmt.Errorf("failed to decode sample: %w", err)
+	}
+	if !utf8.Valid(decoded) {
+		inference.Encoding = EncodingWindows1252
+		inference.report("encoding", 0.7, "the file is not valid UTF-8")
+		decoded, err = io.ReadAll(NewDecodingReader(bytes.NewReader(sample), EncodingWindows1252))
+		if err != nil {
+			return inference, fmt.Errorf("failed to decode sample: %w", err)
+		}
+	}
+	sample = decoded
+
+	separator, rows, width, confidence := inferSeparator(sample)
+	if width == 0 {
+		return inference, fmt.Errorf("no csv rows to infer from")
+	}
+	inference.Separator = separator
+	inference.report("separator", confidence, "%q splits %.0f%% of the rows in %d columns", separator, confidence*100, width)
+
+	// Rows before the first one with the expected number of columns (e.g. a
+	// title or the account number) are skipped, as well as the header.
+	for len(rows[inference.SkipRows]) != width {
+		inference.SkipRows++
+	}
+	rows = rowsWithWidth(rows[inference.SkipRows:], width)
+	if isHeader(rows) {
+		inference.SkipRows++
+		inference.report("header", 0.8, "the first row has no dates nor ammounts")
+		rows = rows[1:]
+	}
+
+	used := map[int]bool{}
+	inference.DateColumn, inference.DateFormat, confidence = inferDateColumn(rows)
+	if inference.DateColumn == -1 {
+		return inference, fmt.Errorf("could not find a date column")
+	}
+	used[inference.DateColumn] = true
+	inference.report("date", confidence, "column %d is read with the format %s", inference.DateColumn, inference.DateFormat)
+
+	inference.AmmountColumn, inference.DecimalComma, confidence = inferAmmountColumn(rows, used)
+	if inference.AmmountColumn == -1 {
+		return inference, fmt.Errorf("could not find an ammount column")
+	}
+	used[inference.AmmountColumn] = true
+	decimalMark := "dot"
+	if inference.DecimalComma {
+		decimalMark = "comma"
+	}
+	inference.report("ammount", confidence, "column %d has numbers with a decimal %s", inference.AmmountColumn, decimalMark)
+
+	inference.DescriptionColumn, confidence = inferDescriptionColumn(rows, used)
+	if inference.DescriptionColumn == -1 {
+		return inference, fmt.Errorf("could not find a description column")
+	}
+	inference.report("description", confidence, "column %d has the longest texts", inference.DescriptionColumn)
+
+	return inference, nil
+}
+
+// inferSeparator returns the separator that splits most rows of the sample
+// in the same number (> 1) of columns, the rows read with it, the number of
+// columns and the fraction of rows that have it. On ties, the separator
+// giving more columns wins.
+func inferSeparator(sample []byte) (rune, [][]string, int, float64) {
+	var bestSeparator rune
+	var bestRows [][]string
+	bestWidth, bestCount := 0, 0
+	for _, separator := range inferSeparators {
+		rows := readSampleRows(sample, separator)
+		width, count := modalWidth(rows)
+		if width < 2 || count < bestCount || (count == bestCount && width <= bestWidth) {
+			continue
+		}
+		bestSeparator, bestRows, bestWidth, bestCount = separator, rows, width, count
+	}
+	if bestWidth == 0 {
+		return ',', nil, 0, 0
+	}
+	return bestSeparator, bestRows, bestWidth, float64(bestCount) / float64(len(bestRows))
+}
+
+func readSampleRows(sample []byte, separator rune) [][]string {
+	csvReader := csv.NewReader(bytes.NewReader(sample))
+	csvReader.Comma = separator
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	rows := [][]string{}
+	for len(rows) < inferSampleRows {
+		row, err := csvReader.Read()
+		if err != nil {
+			break
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// modalWidth returns the most common number of columns of the rows, and how
+// many rows have it.
+func modalWidth(rows [][]string) (int, int) {
+	counts := map[int]int{}
+	width, count := 0, 0
+	for _, row := range rows {
+		counts[len(row)]++
+		if c := counts[len(row)]; c > count || (c == count && len(row) > width) {
+			width, count = len(row), c
+		}
+	}
+	return width, count
+}
+
+func rowsWithWidth(rows [][]string, width int) [][]string {
+	result := [][]string{}
+	for _, row := range rows {
+		if len(row) == width {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// isHeader returns true if the first row has no dates nor ammounts while
+// the second row has some.
+func isHeader(rows [][]string) bool {
+	if len(rows) < 2 {
+		return false
+	}
+	typed := func(row []string) bool {
+		for _, value := range row {
+			if dateFormatOf(value) != "" || ammountRegex.MatchString(strings.TrimSpace(value)) {
+				return true
+			}
+		}
+		return false
+	}
+	return !typed(rows[0]) && typed(rows[1])
+}
+
+func dateFormatOf(value string) string {
+	value = strings.TrimSpace(value)
+	for _, format := range inferDateFormats {
+		if _, err := time.Parse(format, value); err == nil {
+			return format
+		}
+	}
+	return ""
+}
+
+// inferDateColumn returns the first column where most values are dates with
+// the same format, the format and the fraction of values matching it. The
+// confidence is lower if the dates could be read as other dates with another
+// format (e.g. if day and month can't be told apart).
+func inferDateColumn(rows [][]string) (int, string, float64) {
+	for column := range rows[0] {
+		values := columnValues(rows, column)
+		for _, format := range inferDateFormats {
+			fraction := matchingFraction(values, func(value string) bool {
+				_, err := time.Parse(format, value)
+				return err == nil
+			})
+			if fraction < inferMinMatches {
+				continue
+			}
+			if isAmbiguousDateFormat(values, format) {
+				fraction /= 2
+			}
+			return column, format, fraction
+		}
+	}
+	return -1, "", 0
+}
+
+// isAmbiguousDateFormat returns true if all dates can be read with another
+// format, and some of them are read as a different date.
+func isAmbiguousDateFormat(values []string, format string) bool {
+	for _, other := range inferDateFormats {
+		if other == format {
+			continue
+		}
+		allValid, differs := true, false
+		for _, value := range values {
+			date, err := time.Parse(format, value)
+			otherDate, otherErr := time.Parse(other, value)
+			if err != nil {
+				continue
+			}
+			if otherErr != nil {
+				allValid = false
+				break
+			}
+			differs = differs || !date.Equal(otherDate)
+		}
+		if allValid && differs {
+			return true
+		}
+	}
+	return false
+}
+
+// inferAmmountColumn returns the first unused column where most values are
+// ammounts, preferring columns with decimals, whether it uses a decimal
+// comma and the fraction of values that are ammounts.
+func inferAmmountColumn(rows [][]string, used map[int]bool) (int, bool, float64) {
+	bestColumn, bestDecimals, bestFraction := -1, false, 0.0
+	decimalComma := false
+	for column := range rows[0] {
+		if used[column] {
+			continue
+		}
+		values := columnValues(rows, column)
+		fraction := matchingFraction(values, ammountRegex.MatchString)
+		if fraction < inferMinMatches {
+			continue
+		}
+		commas := matchingFraction(values, decimalCommaRegex.MatchString)
+		dots := matchingFraction(values, decimalDotRegex.MatchString)
+		hasDecimals := commas > 0 || dots > 0
+		if bestColumn != -1 && (bestDecimals || !hasDecimals) {
+			continue
+		}
+		bestColumn, bestDecimals, bestFraction = column, hasDecimals, fraction
+		decimalComma = commas > dots
+	}
+	if bestColumn != -1 && !bestDecimals {
+		// Integer columns are often ids, not ammounts.
+		bestFraction /= 2
+	}
+	return bestColumn, decimalComma, bestFraction
+}
+
+// inferDescriptionColumn returns the unused column with the longest texts
+// that are not numbers, and the fraction of its values that are texts.
+func inferDescriptionColumn(rows [][]string, used map[int]bool) (int, float64) {
+	bestColumn, bestLength, bestFraction := -1, 0, 0.0
+	for column := range rows[0] {
+		if used[column] {
+			continue
+		}
+		values := columnValues(rows, column)
+		length := 0
+		for _, value := range values {
+			length += len(value)
+		}
+		isText := func(value string) bool {
+			_, err := decimal.NewFromString(normalizeDecimalMark(value, strings.Contains(value, ",")))
+			return err != nil
+		}
+		fraction := matchingFraction(values, isText)
+		if length > bestLength && fraction >= inferMinMatches {
+			bestColumn, bestLength, bestFraction = column, length, fraction
+		}
+	}
+	return bestColumn, bestFraction
+}
+
+// columnValues returns the non-empty values of a column.
+func columnValues(rows [][]string, column int) []string {
+	values := []string{}
+	for _, row := range rows {
+		if value := strings.TrimSpace(row[column]); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func matchingFraction(values []string, matches func(string) bool) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	count := 0
+	for _, value := range values {
+		if matches(value) {
+			count++
+		}
+	}
+	return float64(count) / float64(len(values))
+}
@@ -0,0 +1,93 @@
+package statementreader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/statementreader"
+)
+
+func TestInferCSV(t *testing.T) {
+	type testcase struct {
+		name     string
+		sample   string
+		expected Inference
+		// minConfidence and maxConfidence bound the overall confidence.
+		minConfidence float64
+		maxConfidence float64
+		expectedError string
+	}
+	testcases := []testcase{
+		{
+			name:          "Simple",
+			sample:        "27/09/2023,PHARMACY,8\n27/09/2023,SUPERMARKET,53.73\n28/09/2023,CLOTHES,8.14\n",
+			expected:      Inference{Separator: ',', DateColumn: 0, DateFormat: "02/01/2006", DescriptionColumn: 1, AmmountColumn: 2},
+			minConfidence: 0.9,
+			maxConfidence: 1,
+		},
+		{
+			name: "Semicolon, header and decimal comma",
+			sample: "Account 123\n" +
+				"Booking;Reference;Text;Value\n" +
+				"2023-09-27;1;Card payment PHARMACY;-1.008,50\n" +
+				"2023-09-28;2;SALARY ACME;2.000,00\n",
+			expected: Inference{
+				Separator:         ';',
+				SkipRows:          2,
+				DateColumn:        0,
+				DateFormat:        "2006-01-02",
+				AmmountColumn:     3,
+				DecimalComma:      true,
+				DescriptionColumn: 2,
+			},
+			minConfidence: 0.5,
+			maxConfidence: 1,
+		},
+		{
+			name:          "Ambiguous day and month",
+			sample:        "01/02/2023\tFOO\t1.00\n03/04/2023\tBAR\t2.00\n",
+			expected:      Inference{Separator: '\t', DateColumn: 0, DateFormat: "02/01/2006", DescriptionColumn: 1, AmmountColumn: 2},
+			minConfidence: 0,
+			maxConfidence: 0.5,
+		},
+		{
+			name:   "Windows-1252",
+			sample: "2023-09-27,CAF\xc9,1.00\n2023-09-28,PA\xd1ALES,2.00\n",
+			expected: Inference{
+				Encoding:          EncodingWindows1252,
+				Separator:         ',',
+				DateColumn:        0,
+				DateFormat:        "2006-01-02",
+				DescriptionColumn: 1,
+				AmmountColumn:     2,
+			},
+			minConfidence: 0.7,
+			maxConfidence: 0.7,
+		},
+		{
+			name:          "No date column",
+			sample:        "FOO,1.00\nBAR,2.00\n",
+			expectedError: "could not find a date column",
+		},
+		{
+			name:          "Empty",
+			sample:        "",
+			expectedError: "no csv rows to infer from",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			inference, err := InferCSV([]byte(tc.sample))
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, inference.Confidence(), tc.minConfidence)
+			assert.LessOrEqual(t, inference.Confidence(), tc.maxConfidence)
+			assert.NotEmpty(t, inference.String())
+			inference.Report = nil
+			assert.Equal(t, tc.expected, inference)
+		})
+	}
+}
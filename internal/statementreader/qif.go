@@ -0,0 +1,158 @@
+package statementreader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/vitorqb/addledger/internal/finance"
+)
+
+// qifSectionTypes are the QIF sections (`!Type:XXX`) we know how to read.
+var qifSectionTypes = map[string]bool{"bank": true, "ccard": true}
+
+// readQIF reads the entries of the `!Type:Bank` and `!Type:CCard` sections of
+// a QIF file. All other sections are ignored. Split lines (`S`, `E` and `$`)
+// are read as the splits of the entry. QIF ammounts are negative for money
+// going out, so they are inverted to match the other formats.
+func readQIF(reader io.Reader, config Config) ([]finance.StatementEntry, error) {
+	if len(config.DateFormats) == 0 {
+		return nil, fmt.Errorf("no date format configured for qif")
+	}
+	var statementEntries []finance.StatementEntry
+	var current qifRecord
+	reading := false
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "!") {
+			header := strings.ToLower(strings.TrimSpace(line))
+			sectionType := strings.TrimSpace(strings.TrimPrefix(header, "!type:"))
+			reading = strings.HasPrefix(header, "!type:") && qifSectionTypes[sectionType]
+			current = qifRecord{}
+			continue
+		}
+		if !reading {
+			continue
+		}
+		code, value := line[0], strings.TrimSpace(line[1:])
+		if code == '^' {
+			statementEntry, err := current.toStatementEntry(config.DateFormats)
+			if err != nil {
+				return nil, fmt.Errorf("error reading qif record ending at line %d: %w", lineNumber, err)
+			}
+			statementEntries = append(statementEntries, statementEntry)
+			current = qifRecord{}
+			continue
+		}
+		current.set(code, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading qif file: %w", err)
+	}
+	return statementEntries, nil
+}
+
+// qifRecord accumulates the fields of a single QIF record.
+type qifRecord struct {
+	date    string
+	ammount string
+	payee   string
+	memo    string
+	splits  []qifSplit
+}
+
+type qifSplit struct {
+	category string
+	memo     string
+	ammount  string
+}
+
+func (r *qifRecord) set(code byte, value string) {
+	switch code {
+	case 'D':
+		r.date = value
+	case 'T', 'U':
+		r.ammount = value
+	case 'P':
+		r.payee = value
+	case 'M':
+		r.memo = value
+	case 'S':
+		r.splits = append(r.splits, qifSplit{category: value})
+	case 'E':
+		if len(r.splits) > 0 {
+			r.splits[len(r.splits)-1].memo = value
+		}
+	case '$':
+		if len(r.splits) > 0 {
+			r.splits[len(r.splits)-1].ammount = value
+		}
+	}
+}
+
+func (r *qifRecord) toStatementEntry(dateFormats []string) (finance.StatementEntry, error) {
+	date, err := parseQIFDate(r.date, dateFormats)
+	if err != nil {
+		return finance.StatementEntry{}, err
+	}
+	quantity, err := parseQIFAmmount(r.ammount)
+	if err != nil {
+		return finance.StatementEntry{}, err
+	}
+	description := r.payee
+	if description == "" {
+		description = r.memo
+	}
+	statementEntry := finance.StatementEntry{
+		Date:        date,
+		Description: description,
+		Ammount:     finance.Ammount{Quantity: quantity.Neg()},
+	}
+	for _, split := range r.splits {
+		splitQuantity, err := parseQIFAmmount(split.ammount)
+		if err != nil {
+			return finance.StatementEntry{}, fmt.Errorf("invalid split: %w", err)
+		}
+		statementEntry.Splits = append(statementEntry.Splits, finance.StatementEntrySplit{
+			Account:     qifCategoryToAccount(split.category),
+			Description: split.memo,
+			Ammount:     finance.Ammount{Quantity: splitQuantity.Neg()},
+		})
+	}
+	return statementEntry, nil
+}
+
+// parseQIFDate tries all date formats, in order. Apostrophes (`1/2'06`) are
+// replaced by slashes and spaces (`1/ 2/06`) are removed before parsing.
+func parseQIFDate(value string, dateFormats []string) (time.Time, error) {
+	normalized := strings.ReplaceAll(strings.ReplaceAll(value, "'", "/"), " ", "")
+	for _, format := range dateFormats {
+		if parsed, err := time.Parse(format, normalized); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date (from formats %s): %s", strings.Join(dateFormats, ", "), value)
+}
+
+func parseQIFAmmount(value string) (decimal.Decimal, error) {
+	quantity, err := decimal.NewFromString(strings.ReplaceAll(value, ",", ""))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount format: %s", value)
+	}
+	return quantity, nil
+}
+
+// qifCategoryToAccount strips the brackets used by QIF for transfers
+// (`[Savings]`).
+func qifCategoryToAccount(category string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(category, "["), "]")
+}
@@ -0,0 +1,146 @@
+package statementreader_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vitorqb/addledger/internal/finance"
+	. "github.com/vitorqb/addledger/internal/statementreader"
+)
+
+const qifStatement = `!Account
+NChecking
+TBank
+^
+!Type:Bank
+D09/27'2023
+T-53.73
+PSUPERMARKET
+MGroceries
+^
+D9/28/2023
+T-1,000.00
+PRENT AND FOOD
+SHome:Rent
+EOctober
+$-900.00
+S[Groceries]
+$-100.00
+^
+!Type:Invst
+D09/29/2023
+PIGNORED
+T10
+^
+!Type:CCard
+D09/30/2023
+T12
+MREFUND
+^
+`
+
+func TestQIFReader(t *testing.T) {
+	type testCase struct {
+		name          string
+		options       []Option
+		input         string
+		expected      []finance.StatementEntry
+		expectedError string
+	}
+	testCases := []testCase{
+		{
+			name: "Reads bank and credit card entries",
+			options: []Option{
+				WithFormat(FormatQIF),
+				WithAccountName("ACC"),
+				WithDefaultCommodity("EUR"),
+				WithDateFormats([]string{"1/2/2006"}),
+			},
+			input: qifStatement,
+			expected: []finance.StatementEntry{
+				{
+					Account:     "ACC",
+					Date:        time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC),
+					Description: "SUPERMARKET",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(5373, -2)},
+				},
+				{
+					Account:     "ACC",
+					Date:        time.Date(2023, 9, 28, 0, 0, 0, 0, time.UTC),
+					Description: "RENT AND FOOD",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(100000, -2)},
+					Splits: []finance.StatementEntrySplit{
+						{
+							Account:     "Home:Rent",
+							Description: "October",
+							Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(90000, -2)},
+						},
+						{
+							Account: "Groceries",
+							Ammount: finance.Ammount{Commodity: "EUR", Quantity: decimal.New(10000, -2)},
+						},
+					},
+				},
+				{
+					Account:     "ACC",
+					Date:        time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC),
+					Description: "REFUND",
+					Ammount:     finance.Ammount{Commodity: "EUR", Quantity: decimal.New(-12, 0)},
+				},
+			},
+		},
+		{
+			name: "Tries date formats in order",
+			options: []Option{
+				WithFormat(FormatQIF),
+				WithDateFormats([]string{"2006-01-02", "02/01/06"}),
+			},
+			input: "!Type:Bank\nD27/09/23\nT1\n^\n",
+			expected: []finance.StatementEntry{
+				{
+					Date:    time.Date(2023, 9, 27, 0, 0, 0, 0, time.UTC),
+					Ammount: finance.Ammount{Quantity: decimal.New(-1, 0)},
+				},
+			},
+		},
+		{
+			name:          "Missing date formats",
+			options:       []Option{WithFormat(FormatQIF)},
+			input:         qifStatement,
+			expectedError: "no date format configured for qif",
+		},
+		{
+			name: "Invalid date",
+			options: []Option{
+				WithFormat(FormatQIF),
+				WithDateFormats([]string{"2006-01-02"}),
+			},
+			input:         "!Type:Bank\nD27/09/23\nT1\n^\n",
+			expectedError: "invalid date (from formats 2006-01-02): 27/09/23",
+		},
+		{
+			name: "Invalid split amount",
+			options: []Option{
+				WithFormat(FormatQIF),
+				WithDateFormats([]string{"2006-01-02"}),
+			},
+			input:         "!Type:Bank\nD2023-09-27\nT1\nSFoo\n$abc\n^\n",
+			expectedError: "invalid split: invalid amount format: abc",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := NewStatementReader().Read(strings.NewReader(tc.input), tc.options...)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, entries)
+		})
+	}
+}
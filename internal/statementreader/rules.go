@@ -0,0 +1,131 @@
+package statementreader
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vitorqb/addledger/internal/finance"
+)
+
+// Rule sets fields of the statement entries whose csv records match it, in
+// the same way as an `if` block of an hledger csv rules file.
+type Rule struct {
+	// Conditions are the alternative conditions of the rule. The rule matches
+	// a record if any of them match. A rule without conditions always matches.
+	Conditions []RuleCondition
+	// CounterAccount, if not empty, is set as the entry counter account.
+	CounterAccount string
+	// Description, if not empty, overrides the entry description. `%N` is
+	// replaced by the value of the Nth (1-based) field of the record.
+	Description string
+}
+
+// RuleCondition matches a record if all of its matchers match.
+type RuleCondition []RuleMatcher
+
+// RuleMatcher matches a record (or one of its fields) against a regex.
+type RuleMatcher struct {
+	// Pattern is the regex to match.
+	Pattern *regexp.Regexp
+	// Column is the index of the field to match. If -1, the whole record
+	// (joined by commas) is matched.
+	Column int
+}
+
+// DescriptionRule rewrites the description of the statement entries, e.g. to
+// strip card numbers or to map a bank description to a canonical payee.
+type DescriptionRule struct {
+	// Pattern is the regex to match in the description.
+	Pattern *regexp.Regexp
+	// Replace replaces each match of the pattern. `$1` is replaced by the
+	// first submatch, as in `regexp.Regexp.ReplaceAllString`.
+	Replace string
+}
+
+var ruleFieldReferenceRegex = regexp.MustCompile(`%([0-9]+)`)
+
+var namedFieldReferenceRegex = regexp.MustCompile(`%([A-Za-z_][A-Za-z0-9_-]*)`)
+
+func (m RuleMatcher) matches(record []string) bool {
+	if m.Column == -1 {
+		return m.Pattern.MatchString(strings.Join(record, ","))
+	}
+	if m.Column >= len(record) {
+		return false
+	}
+	return m.Pattern.MatchString(record[m.Column])
+}
+
+func (c RuleCondition) matches(record []string) bool {
+	for _, matcher := range c {
+		if !matcher.matches(record) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) matches(record []string) bool {
+	if len(r.Conditions) == 0 {
+		return true
+	}
+	for _, condition := range r.Conditions {
+		if condition.matches(record) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules applies all matching rules, in order, to a statement entry.
+func applyRules(rules []Rule, statementEntry *finance.StatementEntry, record []string) {
+	for _, rule := range rules {
+		if !rule.matches(record) {
+			continue
+		}
+		if rule.CounterAccount != "" {
+			statementEntry.CounterAccount = rule.CounterAccount
+		}
+		if rule.Description != "" {
+			statementEntry.Description = interpolateRecord(rule.Description, record)
+		}
+	}
+}
+
+func interpolateRecord(template string, record []string) string {
+	return ruleFieldReferenceRegex.ReplaceAllStringFunc(template, func(ref string) string {
+		index, err := strconv.Atoi(ref[1:])
+		if err != nil || index < 1 || index > len(record) {
+			return ref
+		}
+		return strings.TrimSpace(record[index-1])
+	})
+}
+
+// interpolateFields replaces `%name` by the value of the named field. Unknown
+// fields are replaced by an empty string.
+func interpolateFields(template string, fields map[string]string) string {
+	replaced := namedFieldReferenceRegex.ReplaceAllStringFunc(template, func(ref string) string {
+		return fields[ref[1:]]
+	})
+	return strings.TrimSpace(replaced)
+}
+
+// rewriteDescription applies all description rules, in order, to a statement
+// entry. Repeated spaces left by the rules are collapsed, and the original
+// description is kept if it changed.
+func rewriteDescription(rules []DescriptionRule, statementEntry *finance.StatementEntry) {
+	if len(rules) == 0 {
+		return
+	}
+	description := statementEntry.Description
+	for _, rule := range rules {
+		description = rule.Pattern.ReplaceAllString(description, rule.Replace)
+	}
+	description = strings.Join(strings.Fields(description), " ")
+	if description != statementEntry.Description {
+		statementEntry.OriginalDescription = statementEntry.Description
+		statementEntry.Description = description
+	}
+}
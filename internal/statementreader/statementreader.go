@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/vitorqb/addledger/internal/finance"
 )
@@ -25,16 +26,98 @@ type CSVColumnMapping struct {
 	Importer FieldImporter
 }
 
+// Format represents the format of a statement file.
+type Format string
+
+const (
+	// FormatCSV is a csv statement, read using the configured column mappings.
+	FormatCSV Format = "csv"
+	// FormatCAMT053 is an ISO 20022 CAMT.053 (bank to customer statement) xml.
+	FormatCAMT053 Format = "camt053"
+	// FormatQIF is a Quicken Interchange Format file.
+	FormatQIF Format = "qif"
+)
+
+// ParseFormat parses a Format from a string. An empty string means FormatCSV.
+func ParseFormat(x string) (Format, error) {
+	switch format := Format(strings.ToLower(x)); format {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatCAMT053, FormatQIF:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid statement format: %s", x)
+	}
+}
+
 type StatementReader struct{}
 
 func (s *StatementReader) Read(reader io.Reader, options ...Option) ([]finance.StatementEntry, error) {
 	config := parseOptions(options)
+	reader = NewDecodingReader(reader, config.Encoding)
+
+	// Parse statement entries
+	var statementEntries []finance.StatementEntry
+	var err error
+	switch config.Format {
+	case FormatCSV, "":
+		statementEntries, err = readCSV(reader, config)
+	case FormatCAMT053:
+		statementEntries, err = readCAMT053(reader, config)
+	case FormatQIF:
+		statementEntries, err = readQIF(reader, config)
+	default:
+		err = fmt.Errorf("unsupported statement format: %s", config.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	for i, statementEntry := range statementEntries {
+		if statementEntry.Account == "" {
+			statementEntry.Account = config.AccountName
+		}
+		if statementEntry.Ammount.Commodity == "" {
+			statementEntry.Ammount.Commodity = config.DefaultCommodity
+		}
+		if config.InvertSign {
+			statementEntry.Ammount = statementEntry.Ammount.InvertSign()
+			for j, split := range statementEntry.Splits {
+				statementEntry.Splits[j].Ammount = split.Ammount.InvertSign()
+			}
+		}
+		for j, split := range statementEntry.Splits {
+			if split.Ammount.Commodity == "" {
+				statementEntry.Splits[j].Ammount.Commodity = statementEntry.Ammount.Commodity
+			}
+		}
+		rewriteDescription(config.DescriptionRules, &statementEntry)
+		statementEntry.TagFields = config.TagFields
+		if config.Comment != "" {
+			statementEntry.Comment = interpolateFields(config.Comment, statementEntry.Fields)
+		}
+		statementEntries[i] = statementEntry
+	}
+
+	// Sort
+	if config.SortStrategy != nil {
+		sort.Sort(config.SortStrategy.Clone(statementEntries))
+	}
+
+	return statementEntries, nil
+}
+
+func readCSV(reader io.Reader, config Config) ([]finance.StatementEntry, error) {
 	csvReader := csv.NewReader(reader)
 	csvReader.Comma = config.Separator
+	if config.SkipRows > 0 {
+		// Skipped rows (e.g. headers) often have a different number of fields.
+		csvReader.FieldsPerRecord = -1
+	}
 
-	// Parse statement entries
 	var statementEntries []finance.StatementEntry
-	for {
+	for rowNumber := 0; ; rowNumber++ {
 		record, err := csvReader.Read()
 		if err == io.EOF {
 			break
@@ -42,6 +125,9 @@ func (s *StatementReader) Read(reader io.Reader, options ...Option) ([]finance.S
 		if err != nil {
 			return nil, fmt.Errorf("error reading csv file: %w", err)
 		}
+		if rowNumber < config.SkipRows {
+			continue
+		}
 		var statementEntry finance.StatementEntry
 		for _, columnMapping := range config.ColumnMappings {
 			if columnMapping.Column >= len(record) {
@@ -52,47 +138,53 @@ func (s *StatementReader) Read(reader io.Reader, options ...Option) ([]finance.S
 				return nil, fmt.Errorf("error importing field %T: %w", columnMapping.Importer, err)
 			}
 		}
+		applyRules(config.Rules, &statementEntry, record)
 		statementEntries = append(statementEntries, statementEntry)
 	}
-
-	// Set default values
-	for i, statementEntry := range statementEntries {
-		if statementEntry.Account == "" {
-			statementEntry.Account = config.AccountName
-		}
-		if statementEntry.Ammount.Commodity == "" {
-			statementEntry.Ammount.Commodity = config.DefaultCommodity
-		}
-		statementEntries[i] = statementEntry
-	}
-
-	// Sort
-	if config.SortStrategy != nil {
-		sort.Sort(config.SortStrategy.Clone(statementEntries))
-	}
-
 	return statementEntries, nil
 }
 
 // Config represents the options for a CSVReader.
 type Config struct {
+	// Format is the format of the statement file.
+	Format Format
+	// Encoding is the character encoding of the statement file.
+	Encoding Encoding
 	// AccountName is the default account name for the statement entries.
 	AccountName string
 	// DefaultCommodity is the default commodity for the statement entries.
 	DefaultCommodity string
 	// Separator is the csv separator.
 	Separator rune
+	// DateFormats are the date formats to try, in order, for formats that
+	// don't have column mappings (e.g. qif).
+	DateFormats []string
+	// SkipRows is the number of csv rows to skip (e.g. headers).
+	SkipRows int
 	// ColumnMappings is the csv column mappings.
 	ColumnMappings []CSVColumnMapping
+	// Rules are applied, in order, to each csv record after the mappings.
+	Rules []Rule
+	// DescriptionRules are applied, in order, to the description of each
+	// entry, for all formats.
+	DescriptionRules []DescriptionRule
+	// TagFields are the names of the fields used as transaction tags.
+	TagFields []string
+	// Comment is a template for the transaction comment, where `%name` is
+	// the value of the named field.
+	Comment string
+	// InvertSign negates the ammounts, for statements where money going out
+	// is negative (e.g. most bank accounts).
+	InvertSign bool
 	// Sort strategy to use (if any)
 	SortStrategy SortStrategy
 }
 
 var DefaultConfig = Config{
-	AccountName:      "",
-	DefaultCommodity: "EUR",
-	Separator:        ',',
-	ColumnMappings:   []CSVColumnMapping{},
+	Format:         FormatCSV,
+	AccountName:    "",
+	Separator:      ',',
+	ColumnMappings: []CSVColumnMapping{},
 }
 
 // A SortStrategy represents a strategy for sorting an array of StatementEntry.
@@ -113,6 +205,18 @@ func (SortByDate) Clone(x []finance.StatementEntry) SortStrategy { return SortBy
 // Option is a function that configures a CSVLoaderConfig.
 type Option func(*Config)
 
+func WithFormat(format Format) Option {
+	return func(o *Config) {
+		o.Format = format
+	}
+}
+
+func WithEncoding(encoding Encoding) Option {
+	return func(o *Config) {
+		o.Encoding = encoding
+	}
+}
+
 func WithAccountName(accountName string) Option {
 	return func(o *Config) {
 		o.AccountName = accountName
@@ -131,6 +235,48 @@ func WithSeparator(separator rune) Option {
 	}
 }
 
+func WithDateFormats(dateFormats []string) Option {
+	return func(o *Config) {
+		o.DateFormats = dateFormats
+	}
+}
+
+func WithSkipRows(skipRows int) Option {
+	return func(o *Config) {
+		o.SkipRows = skipRows
+	}
+}
+
+func WithRules(rules []Rule) Option {
+	return func(o *Config) {
+		o.Rules = rules
+	}
+}
+
+func WithDescriptionRules(descriptionRules []DescriptionRule) Option {
+	return func(o *Config) {
+		o.DescriptionRules = descriptionRules
+	}
+}
+
+func WithTagFields(tagFields []string) Option {
+	return func(o *Config) {
+		o.TagFields = tagFields
+	}
+}
+
+func WithComment(comment string) Option {
+	return func(o *Config) {
+		o.Comment = comment
+	}
+}
+
+func WithInvertSign(invertSign bool) Option {
+	return func(o *Config) {
+		o.InvertSign = invertSign
+	}
+}
+
 func WithLoaderMapping(columnMappings []CSVColumnMapping) Option {
 	return func(o *Config) {
 		o.ColumnMappings = columnMappings
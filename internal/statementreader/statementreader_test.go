@@ -2,6 +2,7 @@ package statementreader_test
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -83,6 +84,7 @@ func TestAccountImporter(t *testing.T) {
 func TestAmmountImporter(t *testing.T) {
 	type testCase struct {
 		ammountStr      string
+		decimalComma    bool
 		expectedAmmount finance.Ammount
 		expectedError   string
 	}
@@ -97,14 +99,33 @@ func TestAmmountImporter(t *testing.T) {
 			expectedAmmount: finance.Ammount{Commodity: "", Quantity: decimal.New(122, -1)},
 			expectedError:   "",
 		},
+		{
+			ammountStr:      "-1.234,56",
+			decimalComma:    true,
+			expectedAmmount: finance.Ammount{Commodity: "", Quantity: decimal.New(-123456, -2)},
+			expectedError:   "",
+		},
+		{
+			ammountStr:      "1e3",
+			expectedAmmount: finance.Ammount{Commodity: "", Quantity: decimal.New(1, 3)},
+			expectedError:   "",
+		},
 		{
 			ammountStr:    "FOO",
 			expectedError: "invalid amount format: FOO",
 		},
+		{
+			ammountStr:    "(12.50)",
+			expectedError: "invalid amount format: (12.50)",
+		},
+		{
+			ammountStr:    "10-2",
+			expectedError: "invalid amount format: 10-2",
+		},
 	}
 	for _, tc := range testCases {
 		statementEntry := &finance.StatementEntry{}
-		err := AmmountImporter{}.Import(statementEntry, tc.ammountStr)
+		err := AmmountImporter{DecimalComma: tc.decimalComma}.Import(statementEntry, tc.ammountStr)
 		assert.Equal(t, tc.expectedAmmount, statementEntry.Ammount)
 		if tc.expectedError != "" {
 			assert.ErrorContains(t, err, tc.expectedError)
@@ -114,6 +135,64 @@ func TestAmmountImporter(t *testing.T) {
 	}
 }
 
+func TestAmmountInOutImporter(t *testing.T) {
+	type testCase struct {
+		name            string
+		importer        FieldImporter
+		ammountStr      string
+		expectedAmmount finance.Ammount
+		expectedError   string
+	}
+	testCases := []testCase{
+		{
+			name:            "in",
+			importer:        AmmountInImporter{},
+			ammountStr:      "12.2",
+			expectedAmmount: finance.Ammount{Quantity: decimal.New(-122, -1)},
+		},
+		{
+			name:            "out",
+			importer:        AmmountOutImporter{},
+			ammountStr:      "EUR 12.2",
+			expectedAmmount: finance.Ammount{Commodity: "EUR", Quantity: decimal.New(122, -1)},
+		},
+		{
+			name:            "out already negative",
+			importer:        AmmountOutImporter{},
+			ammountStr:      "-12.2",
+			expectedAmmount: finance.Ammount{Quantity: decimal.New(122, -1)},
+		},
+		{
+			name:       "empty is ignored",
+			importer:   AmmountInImporter{},
+			ammountStr: " ",
+		},
+		{
+			name:       "zero is ignored",
+			importer:   AmmountOutImporter{},
+			ammountStr: "0.00",
+		},
+		{
+			name:          "invalid",
+			importer:      AmmountInImporter{},
+			ammountStr:    "FOO",
+			expectedError: "invalid amount format: FOO",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statementEntry := &finance.StatementEntry{}
+			err := tc.importer.Import(statementEntry, tc.ammountStr)
+			assert.Equal(t, tc.expectedAmmount, statementEntry.Ammount)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCSVLoader(t *testing.T) {
 	type testCase struct {
 		name          string
@@ -197,6 +276,120 @@ func TestCSVLoader(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Skip rows and rules",
+			options: []Option{
+				WithSkipRows(1),
+				WithLoaderMapping([]CSVColumnMapping{
+					{Column: 0, Importer: DescriptionImporter{}},
+					{Column: 1, Importer: AmmountInImporter{}},
+					{Column: 2, Importer: AmmountOutImporter{}},
+				}),
+				WithRules([]Rule{
+					{
+						Conditions:     []RuleCondition{{{Pattern: regexp.MustCompile("(?i)market"), Column: 0}}},
+						CounterAccount: "groceries",
+					},
+					{
+						Conditions: []RuleCondition{
+							{{Pattern: regexp.MustCompile("^FOO"), Column: -1}},
+							{{Pattern: regexp.MustCompile("^SALARY,"), Column: -1}},
+						},
+						Description: "Income: %1 (%2)",
+					},
+				}),
+			},
+			csvInput: "Description,In,Out,Extra\nSUPERMARKET,,12.21\nSALARY,100,",
+			expected: []finance.StatementEntry{
+				{
+					Description:    "SUPERMARKET",
+					CounterAccount: "groceries",
+					Ammount:        finance.Ammount{Quantity: decimal.New(1221, -2)},
+				},
+				{
+					Description: "Income: SALARY (100)",
+					Ammount:     finance.Ammount{Quantity: decimal.New(-100, 0)},
+				},
+			},
+		},
+		{
+			name: "Extra fields, tags and comment",
+			options: []Option{
+				WithLoaderMapping([]CSVColumnMapping{
+					{Column: 0, Importer: DescriptionImporter{}},
+					{Column: 1, Importer: NamedFieldImporter{Name: "bankref"}},
+					{Column: 2, Importer: NamedFieldImporter{Name: "balance"}},
+				}),
+				WithTagFields([]string{"bankref"}),
+				WithComment("balance: %balance %unknown"),
+			},
+			csvInput: "FOO,REF1,100.00\nBAR, ,",
+			expected: []finance.StatementEntry{
+				{
+					Description: "FOO",
+					Fields:      map[string]string{"bankref": "REF1", "balance": "100.00"},
+					TagFields:   []string{"bankref"},
+					Comment:     "balance: 100.00",
+				},
+				{
+					Description: "BAR",
+					TagFields:   []string{"bankref"},
+					Comment:     "balance:",
+				},
+			},
+		},
+		{
+			name: "Invert sign",
+			options: []Option{
+				WithInvertSign(true),
+				WithLoaderMapping([]CSVColumnMapping{
+					{Column: 0, Importer: AmmountImporter{}},
+				}),
+			},
+			csvInput: "12.21\n-3",
+			expectFn: func(x []finance.StatementEntry) {
+				assert.Equal(t, "-12.21", x[0].Ammount.Quantity.String())
+				assert.Equal(t, "3", x[1].Ammount.Quantity.String())
+			},
+		},
+		{
+			name: "Description rules",
+			options: []Option{
+				WithDescriptionRules([]DescriptionRule{
+					{Pattern: regexp.MustCompile(`[0-9]{4}X+[0-9]{4}`), Replace: ""},
+					{Pattern: regexp.MustCompile(`[0-9]{2}/[0-9]{2}$`), Replace: ""},
+					{Pattern: regexp.MustCompile(`^COMPRA TARJ\. (.*)$`), Replace: "$1"},
+					{Pattern: regexp.MustCompile(`.*MERCADONA.*`), Replace: "Mercadona"},
+				}),
+				WithLoaderMapping([]CSVColumnMapping{
+					{Column: 0, Importer: DescriptionImporter{}},
+				}),
+			},
+			csvInput: "COMPRA TARJ. 5402XXXXXXXX1234 MERCADONA SA 27/09\nCOMPRA TARJ. 5402XXXXXXXX1234 ZARA  27/09\nSalary",
+			expectFn: func(x []finance.StatementEntry) {
+				assert.Equal(t, "Mercadona", x[0].Description)
+				assert.Equal(t, "COMPRA TARJ. 5402XXXXXXXX1234 MERCADONA SA 27/09", x[0].OriginalDescription)
+				assert.Equal(t, "ZARA", x[1].Description)
+				assert.Equal(t, "Salary", x[2].Description)
+				assert.Equal(t, "", x[2].OriginalDescription)
+			},
+		},
+		{
+			name: "Commodity and invert sign fields",
+			options: []Option{
+				WithDefaultCommodity("EUR"),
+				WithLoaderMapping([]CSVColumnMapping{
+					{Column: 0, Importer: AmmountImporter{}},
+					{Column: 1, Importer: CommodityImporter{}},
+					{Column: 2, Importer: InvertSignImporter{Values: []string{"debit"}}},
+				}),
+			},
+			csvInput: "12.21,USD,DEBIT\n3,,credit",
+			expected: []finance.StatementEntry{
+				{Ammount: finance.Ammount{Commodity: "USD", Quantity: decimal.RequireFromString("-12.21")}},
+				{Ammount: finance.Ammount{Commodity: "EUR", Quantity: decimal.RequireFromString("3")}},
+			},
+		},
 		{
 			name: "Column out of range",
 			options: []Option{
@@ -0,0 +1,154 @@
+package userinput
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExpressionPrecision is the number of decimal places kept when the result of
+// an expression has a division that does not terminate (e.g. `100/3`).
+const ExpressionPrecision = 2
+
+// EvalExpression evaluates an arithmetic expression with exact decimals.
+// Supports `+`, `-`, `*`, `/` and parentheses, e.g. `12.40 + 3.99*2`. Spaces
+// between numbers and operators are ignored. If a division does not terminate,
+// the result is rounded to ExpressionPrecision decimal places.
+func EvalExpression(x string) (decimal.Decimal, error) {
+	parser := &expressionParser{text: x}
+	result, err := parser.parseSum()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if parser.peek() != 0 {
+		return decimal.Zero, fmt.Errorf("unexpected character at position %d: %c", parser.pos, parser.text[parser.pos])
+	}
+	if parser.inexact {
+		result = result.Round(ExpressionPrecision)
+	}
+	return result, nil
+}
+
+// IsExpression returns whether a text is an arithmetic expression, and not
+// just a number.
+func IsExpression(x string) bool {
+	if _, err := decimal.NewFromString(x); err == nil {
+		return false
+	}
+	_, err := EvalExpression(x)
+	return err == nil
+}
+
+// expressionParser is a recursive descent parser for arithmetic expressions.
+type expressionParser struct {
+	text string
+	pos  int
+	// inexact is set when a division does not terminate.
+	inexact bool
+}
+
+// peek returns the next character, skipping spaces, or 0 at the end.
+func (p *expressionParser) peek() byte {
+	for p.pos < len(p.text) && p.text[p.pos] == ' ' {
+		p.pos++
+	}
+	if p.pos >= len(p.text) {
+		return 0
+	}
+	return p.text[p.pos]
+}
+
+// parseSum parses `term (('+'|'-') term)*`.
+func (p *expressionParser) parseSum() (decimal.Decimal, error) {
+	result, err := p.parseProduct()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for {
+		operator := p.peek()
+		if operator != '+' && operator != '-' {
+			return result, nil
+		}
+		p.pos++
+		operand, err := p.parseProduct()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if operator == '+' {
+			result = result.Add(operand)
+		} else {
+			result = result.Sub(operand)
+		}
+	}
+}
+
+// parseProduct parses `factor (('*'|'/') factor)*`.
+func (p *expressionParser) parseProduct() (decimal.Decimal, error) {
+	result, err := p.parseFactor()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for {
+		operator := p.peek()
+		if operator != '*' && operator != '/' {
+			return result, nil
+		}
+		p.pos++
+		operand, err := p.parseFactor()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if operator == '*' {
+			result = result.Mul(operand)
+			continue
+		}
+		if operand.IsZero() {
+			return decimal.Zero, fmt.Errorf("division by zero")
+		}
+		quotient := result.Div(operand)
+		if !quotient.Mul(operand).Equal(result) {
+			p.inexact = true
+		}
+		result = quotient
+	}
+}
+
+// parseFactor parses a signed number or a parenthesized expression.
+func (p *expressionParser) parseFactor() (decimal.Decimal, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		factor, err := p.parseFactor()
+		return factor.Neg(), err
+	case '+':
+		p.pos++
+		return p.parseFactor()
+	case '(':
+		p.pos++
+		result, err := p.parseSum()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if p.peek() != ')' {
+			return decimal.Zero, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return result, nil
+	}
+	start := p.pos
+	for p.pos < len(p.text) && strings.IndexByte("0123456789.", p.text[p.pos]) != -1 {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos >= len(p.text) {
+			return decimal.Zero, fmt.Errorf("unexpected end of expression")
+		}
+		return decimal.Zero, fmt.Errorf("unexpected character at position %d: %c", p.pos, p.text[p.pos])
+	}
+	number, err := decimal.NewFromString(p.text[start:p.pos])
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid number: %w", err)
+	}
+	return number, nil
+}
@@ -0,0 +1,58 @@
+package userinput_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vitorqb/addledger/internal/userinput"
+)
+
+func TestEvalExpression(t *testing.T) {
+	type testcase struct {
+		text     string
+		result   string
+		errorMsg string
+	}
+	var testcases = []testcase{
+		{text: "12.20", result: "12.2"},
+		{text: "-12.20", result: "-12.2"},
+		{text: "12.40+3.99*2", result: "20.38"},
+		{text: "(12.40+3.99)*2", result: "32.78"},
+		{text: "10-2-3", result: "5"},
+		{text: "100/3", result: "33.33"},
+		{text: "1/8", result: "0.125"},
+		{text: "100/3*3", result: "100"},
+		{text: "-(1+2)*-2", result: "6"},
+		{text: "0.1+0.2", result: "0.3"},
+		{text: "", errorMsg: "unexpected end of expression"},
+		{text: "1+", errorMsg: "unexpected end of expression"},
+		{text: "(1+2", errorMsg: "missing closing parenthesis"},
+		{text: "1+2)", errorMsg: "unexpected character at position 3: )"},
+		{text: "1 + 2", result: "3"},
+		{text: " ( 12.40 + 3.99 ) * 2 ", result: "32.78"},
+		{text: "1 2", errorMsg: "unexpected character at position 2: 2"},
+		{text: "1/0", errorMsg: "division by zero"},
+		{text: "1..2", errorMsg: "invalid number"},
+		{text: "EUR", errorMsg: "unexpected character at position 0: E"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			result, err := EvalExpression(tc.text)
+			if tc.errorMsg == "" {
+				assert.Nil(t, err)
+				assert.True(t, decimal.RequireFromString(tc.result).Equal(result), result.String())
+			} else {
+				assert.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestIsExpression(t *testing.T) {
+	assert.True(t, IsExpression("1+2"))
+	assert.True(t, IsExpression("1/2"))
+	assert.False(t, IsExpression("12.20"))
+	assert.False(t, IsExpression("-12.20"))
+	assert.False(t, IsExpression("1+"))
+}
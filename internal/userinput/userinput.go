@@ -10,6 +10,7 @@ import (
 	"github.com/vitorqb/addledger/internal/state"
 
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -39,10 +40,14 @@ func TransactionRepr(t *state.TransactionData) string {
 	if description, found := t.Description.Get(); found {
 		out += " " + description
 	}
-	for i, tag := range t.Tags.Get() {
-		if i == 0 {
-			out += " ;"
-		}
+	comment, _ := t.Comment.Get()
+	if comment != "" || len(t.Tags.Get()) > 0 {
+		out += " ;"
+	}
+	if comment != "" {
+		out += " " + comment
+	}
+	for _, tag := range t.Tags.Get() {
 		out += " " + tag.Name + ":" + tag.Value
 	}
 	for _, posting := range t.Postings.Get() {
@@ -115,9 +120,12 @@ func TransactionFromData(t *state.TransactionData) (journal.Transaction, error)
 		return journal.Transaction{}, fmt.Errorf("missing date")
 	}
 
+	comment, _ := t.Comment.Get()
+
 	return journal.Transaction{
 		Description: description,
 		Date:        date,
+		Comment:     comment,
 		Tags:        t.Tags.Get(),
 		Posting:     postings,
 	}, nil
@@ -201,6 +209,8 @@ const (
 	Input   DoneSource = "input"
 )
 
+// TextToAmmount parses an ammount with an optional commodity prefix, e.g.
+// `EUR 12.20`.
 func TextToAmmount(x string) (finance.Ammount, error) {
 	var err error
 	var quantity decimal.Decimal
@@ -220,24 +230,77 @@ func TextToAmmount(x string) (finance.Ammount, error) {
 	return finance.Ammount{Commodity: commodity, Quantity: quantity}, nil
 }
 
-func TextToFraction(x string) (decimal.Decimal, error) {
-	if !strings.Contains(x, "/") {
-		return decimal.Zero, fmt.Errorf("invalid fraction")
+// TextToAmmountExpression is like TextToAmmount, but the quantity may be an
+// arithmetic expression (see EvalExpression), e.g. `EUR 12.40 + 3.99`. Meant
+// for what the user types, not for parsing statements.
+func TextToAmmountExpression(x string) (finance.Ammount, error) {
+	commodity, expression := SplitCommodity(x)
+	quantity, err := EvalExpression(expression)
+	if err != nil {
+		return finance.Ammount{}, fmt.Errorf("invalid format: %w", err)
 	}
-	var err error
-	var numerator, denominator decimal.Decimal
-	switch words := strings.Split(x, "/"); len(words) {
-	case 2:
-		numerator, err = decimal.NewFromString(words[0])
-		if err != nil {
-			return decimal.Zero, fmt.Errorf("invalid fraction: %w", err)
-		}
-		denominator, err = decimal.NewFromString(words[1])
-		if err != nil {
-			return decimal.Zero, fmt.Errorf("invalid fraction: %w", err)
-		}
-	default:
-		return decimal.Zero, fmt.Errorf("invalid fraction")
+	return finance.Ammount{Commodity: commodity, Quantity: quantity}, nil
+}
+
+// SplitCommodity splits the optional commodity prefix (e.g. `EUR`) of an
+// ammount text from its quantity. The first word is the commodity unless it
+// starts like a number or an expression.
+func SplitCommodity(x string) (commodity, quantity string) {
+	x = strings.TrimSpace(x)
+	i := strings.Index(x, " ")
+	if i == -1 || strings.IndexByte("0123456789.+-(", x[0]) != -1 {
+		return "", x
+	}
+	return x[:i], strings.TrimSpace(x[i+1:])
+}
+
+// TextToShare parses a share of a whole, which is a fraction `a/b` with
+// 0 < a <= b (e.g. `1/3`). Other fractions (e.g. `100/3`) are not shares.
+func TextToShare(x string) (decimal.Decimal, error) {
+	words := strings.Split(x, "/")
+	if len(words) != 2 {
+		return decimal.Zero, fmt.Errorf("invalid share")
+	}
+	numerator, err := decimal.NewFromString(words[0])
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid share: %w", err)
+	}
+	denominator, err := decimal.NewFromString(words[1])
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid share: %w", err)
+	}
+	if !numerator.IsPositive() || numerator.GreaterThan(denominator) {
+		return decimal.Zero, fmt.Errorf("invalid share: must be between 0 and 1")
 	}
 	return numerator.Div(denominator), nil
 }
+
+// RestText is the text for taking the full pending balance.
+const RestText = "rest"
+
+// TextToPercentage parses a percentage (e.g. `30%`) to a fraction (e.g. `0.3`).
+func TextToPercentage(x string) (decimal.Decimal, error) {
+	if !strings.HasSuffix(x, "%") {
+		return decimal.Zero, fmt.Errorf("invalid percentage")
+	}
+	percentage, err := decimal.NewFromString(strings.TrimSuffix(x, "%"))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid percentage: %w", err)
+	}
+	return percentage.Div(decimal.New(100, 0)), nil
+}
+
+// TextToSplit parses an even split (e.g. `=3`) to the number of postings.
+func TextToSplit(x string) (int, error) {
+	if !strings.HasPrefix(x, "=") {
+		return 0, fmt.Errorf("invalid split")
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(x, "="))
+	if err != nil {
+		return 0, fmt.Errorf("invalid split: %w", err)
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("invalid split: must be at least 1")
+	}
+	return n, nil
+}
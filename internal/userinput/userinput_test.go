@@ -50,6 +50,16 @@ func TestTransactionRepr(t *testing.T) {
 			},
 			expected: "1993-11-23 foo ; bar:baz",
 		},
+		{
+			name: "With comment and tags",
+			transaction: func(_ *testing.T, tra *state.TransactionData) {
+				tra.Date.Set(testutils.Date1(t))
+				tra.Description.Set("foo")
+				tra.Comment.Set("a comment")
+				tra.Tags.Append(journal.Tag{Name: "bar", Value: "baz"})
+			},
+			expected: "1993-11-23 foo ; a comment bar:baz",
+		},
 		{
 			name: "With postings",
 			transaction: func(_ *testing.T, tra *state.TransactionData) {
@@ -129,6 +139,19 @@ func TestTransactionFromData(t *testing.T) {
 				return out
 			},
 		},
+		{
+			name: "Simple transaction with comment",
+			data: func(t *testing.T) *state.TransactionData {
+				out := tu.TransactionData_1(t)
+				out.Comment.Set("foo")
+				return out
+			},
+			expectedTransaction: func(t *testing.T) *journal.Transaction {
+				out := tu.Transaction_1(t)
+				out.Comment = "foo"
+				return out
+			},
+		},
 		{
 			name: "Missing description",
 			data: func(t *testing.T) *state.TransactionData {
@@ -379,6 +402,21 @@ func TestTextToAmmount(t *testing.T) {
 				Quantity:  decimal.New(1220, -2),
 			},
 		},
+		{
+			text: "1e3",
+			ammount: finance.Ammount{
+				Commodity: "",
+				Quantity:  decimal.New(1, 3),
+			},
+		},
+		{
+			text:     "12.40+3.99*2",
+			errorMsg: "invalid format",
+		},
+		{
+			text:     "(12.50)",
+			errorMsg: "invalid format",
+		},
 		{
 			text:     "12,20",
 			errorMsg: "invalid format",
@@ -421,6 +459,166 @@ func TestTextToAmmount(t *testing.T) {
 	}
 }
 
+func TestTextToAmmountExpression(t *testing.T) {
+	type testcase struct {
+		text     string
+		ammount  finance.Ammount
+		errorMsg string
+	}
+	var testcases = []testcase{
+		{
+			text: "12.40+3.99*2",
+			ammount: finance.Ammount{
+				Commodity: "",
+				Quantity:  decimal.New(2038, -2),
+			},
+		},
+		{
+			text: "EUR 100/3",
+			ammount: finance.Ammount{
+				Commodity: "EUR",
+				Quantity:  decimal.New(3333, -2),
+			},
+		},
+		{
+			text: "EUR 12.40 + 3.99",
+			ammount: finance.Ammount{
+				Commodity: "EUR",
+				Quantity:  decimal.New(1639, -2),
+			},
+		},
+		{
+			text: "12.40 + 3.99",
+			ammount: finance.Ammount{
+				Commodity: "",
+				Quantity:  decimal.New(1639, -2),
+			},
+		},
+		{
+			text:     "EUR 100/0",
+			errorMsg: "division by zero",
+		},
+		{
+			text:     "EUR 12 12",
+			errorMsg: "invalid format",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			result, err := TextToAmmountExpression(tc.text)
+			if tc.errorMsg == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.ammount, result)
+			} else {
+				assert.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestSplitCommodity(t *testing.T) {
+	type testcase struct {
+		text      string
+		commodity string
+		quantity  string
+	}
+	var testcases = []testcase{
+		{text: "12.20", commodity: "", quantity: "12.20"},
+		{text: "EUR 12.20", commodity: "EUR", quantity: "12.20"},
+		{text: " EUR  12.40 + 3.99 ", commodity: "EUR", quantity: "12.40 + 3.99"},
+		{text: "12.40 + 3.99", commodity: "", quantity: "12.40 + 3.99"},
+		{text: "(1 + 2) * 3", commodity: "", quantity: "(1 + 2) * 3"},
+		{text: "- 3", commodity: "", quantity: "- 3"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			commodity, quantity := SplitCommodity(tc.text)
+			assert.Equal(t, tc.commodity, commodity)
+			assert.Equal(t, tc.quantity, quantity)
+		})
+	}
+}
+
+func TestTextToShare(t *testing.T) {
+	type testcase struct {
+		text     string
+		share    decimal.Decimal
+		errorMsg string
+	}
+	var testcases = []testcase{
+		{text: "1/3", share: decimal.New(1, 0).Div(decimal.New(3, 0))},
+		{text: "100/200", share: decimal.New(5, -1)},
+		{text: "3/3", share: decimal.New(1, 0)},
+		{text: "100/3", errorMsg: "must be between 0 and 1"},
+		{text: "0/3", errorMsg: "must be between 0 and 1"},
+		{text: "-1/-2", errorMsg: "must be between 0 and 1"},
+		{text: "1/0", errorMsg: "must be between 0 and 1"},
+		{text: "1/2/3", errorMsg: "invalid share"},
+		{text: "a/2", errorMsg: "invalid share"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			share, err := TextToShare(tc.text)
+			if tc.errorMsg == "" {
+				assert.Nil(t, err)
+				assert.True(t, tc.share.Equal(share), "%s != %s", tc.share, share)
+			} else {
+				assert.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestTextToPercentage(t *testing.T) {
+	type testcase struct {
+		text     string
+		fraction decimal.Decimal
+		errorMsg string
+	}
+	var testcases = []testcase{
+		{text: "30%", fraction: decimal.New(3, -1)},
+		{text: "12.5%", fraction: decimal.New(125, -3)},
+		{text: "30", errorMsg: "invalid percentage"},
+		{text: "a%", errorMsg: "invalid percentage"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			result, err := TextToPercentage(tc.text)
+			if tc.errorMsg == "" {
+				assert.Nil(t, err)
+				assert.True(t, tc.fraction.Equal(result), result.String())
+			} else {
+				assert.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestTextToSplit(t *testing.T) {
+	type testcase struct {
+		text     string
+		n        int
+		errorMsg string
+	}
+	var testcases = []testcase{
+		{text: "=3", n: 3},
+		{text: "3", errorMsg: "invalid split"},
+		{text: "=a", errorMsg: "invalid split"},
+		{text: "=0", errorMsg: "must be at least 1"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.text, func(t *testing.T) {
+			result, err := TextToSplit(tc.text)
+			if tc.errorMsg == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.n, result)
+			} else {
+				assert.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
 func TestPostingFromData(t *testing.T) {
 	t.Run("Missing ammount", func(t *testing.T) {
 		data := state.NewPostingData()
@@ -49,3 +49,17 @@ func (mr *MockAccountGuesserMockRecorder) Guess(inputs interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Guess", reflect.TypeOf((*MockAccountGuesser)(nil).Guess), inputs)
 }
+
+// Rank mocks base method.
+func (m *MockAccountGuesser) Rank(inputs accountguesser.Inputs) []accountguesser.Candidate {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rank", inputs)
+	ret0, _ := ret[0].([]accountguesser.Candidate)
+	return ret0
+}
+
+// Rank indicates an expected call of Rank.
+func (mr *MockAccountGuesserMockRecorder) Rank(inputs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rank", reflect.TypeOf((*MockAccountGuesser)(nil).Rank), inputs)
+}
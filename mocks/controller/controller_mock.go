@@ -9,6 +9,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	listaction "github.com/vitorqb/addledger/internal/listaction"
+	statementloader "github.com/vitorqb/addledger/internal/services/statementloader"
+	state "github.com/vitorqb/addledger/internal/state"
 	userinput "github.com/vitorqb/addledger/internal/userinput"
 )
 
@@ -36,17 +38,47 @@ func (m *MockStatementLoader) EXPECT() *MockStatementLoaderMockRecorder {
 }
 
 // LoadFromFiles mocks base method.
-func (m *MockStatementLoader) LoadFromFiles(statementFile, presetFile string) error {
+func (m *MockStatementLoader) LoadFromFiles(statementFile, presetFile, format string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadFromFiles", statementFile, presetFile)
+	ret := m.ctrl.Call(m, "LoadFromFiles", statementFile, presetFile, format)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LoadFromFiles indicates an expected call of LoadFromFiles.
-func (mr *MockStatementLoaderMockRecorder) LoadFromFiles(statementFile, presetFile interface{}) *gomock.Call {
+func (mr *MockStatementLoaderMockRecorder) LoadFromFiles(statementFile, presetFile, format interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFromFiles", reflect.TypeOf((*MockStatementLoader)(nil).LoadFromFiles), statementFile, presetFile)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFromFiles", reflect.TypeOf((*MockStatementLoader)(nil).LoadFromFiles), statementFile, presetFile, format)
+}
+
+// Preview mocks base method.
+func (m *MockStatementLoader) Preview(config statementloader.Config, n int) (state.PresetPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", config, n)
+	ret0, _ := ret[0].(state.PresetPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockStatementLoaderMockRecorder) Preview(config, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockStatementLoader)(nil).Preview), config, n)
+}
+
+// SavePreset mocks base method.
+func (m *MockStatementLoader) SavePreset(name string, config statementloader.Config) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreset", name, config)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePreset indicates an expected call of SavePreset.
+func (mr *MockStatementLoaderMockRecorder) SavePreset(name, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreset", reflect.TypeOf((*MockStatementLoader)(nil).SavePreset), name, config)
 }
 
 // MockIInputController is a mock of IInputController interface.
@@ -72,6 +104,18 @@ func (m *MockIInputController) EXPECT() *MockIInputControllerMockRecorder {
 	return m.recorder
 }
 
+// OnCancelStatementEntryEdit mocks base method.
+func (m *MockIInputController) OnCancelStatementEntryEdit() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnCancelStatementEntryEdit")
+}
+
+// OnCancelStatementEntryEdit indicates an expected call of OnCancelStatementEntryEdit.
+func (mr *MockIInputControllerMockRecorder) OnCancelStatementEntryEdit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnCancelStatementEntryEdit", reflect.TypeOf((*MockIInputController)(nil).OnCancelStatementEntryEdit))
+}
+
 // OnDateChanged mocks base method.
 func (m *MockIInputController) OnDateChanged(text string) {
 	m.ctrl.T.Helper()
@@ -120,6 +164,18 @@ func (mr *MockIInputControllerMockRecorder) OnDescriptionDone(source interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDescriptionDone", reflect.TypeOf((*MockIInputController)(nil).OnDescriptionDone), source)
 }
 
+// OnDescriptionFillFromMatch mocks base method.
+func (m *MockIInputController) OnDescriptionFillFromMatch() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnDescriptionFillFromMatch")
+}
+
+// OnDescriptionFillFromMatch indicates an expected call of OnDescriptionFillFromMatch.
+func (mr *MockIInputControllerMockRecorder) OnDescriptionFillFromMatch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDescriptionFillFromMatch", reflect.TypeOf((*MockIInputController)(nil).OnDescriptionFillFromMatch))
+}
+
 // OnDescriptionInsertFromContext mocks base method.
 func (m *MockIInputController) OnDescriptionInsertFromContext() {
 	m.ctrl.T.Helper()
@@ -144,6 +200,18 @@ func (mr *MockIInputControllerMockRecorder) OnDescriptionListAction(action inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDescriptionListAction", reflect.TypeOf((*MockIInputController)(nil).OnDescriptionListAction), action)
 }
 
+// OnDiscardDuplicatedStatementEntries mocks base method.
+func (m *MockIInputController) OnDiscardDuplicatedStatementEntries() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnDiscardDuplicatedStatementEntries")
+}
+
+// OnDiscardDuplicatedStatementEntries indicates an expected call of OnDiscardDuplicatedStatementEntries.
+func (mr *MockIInputControllerMockRecorder) OnDiscardDuplicatedStatementEntries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDiscardDuplicatedStatementEntries", reflect.TypeOf((*MockIInputController)(nil).OnDiscardDuplicatedStatementEntries))
+}
+
 // OnDiscardStatementEntry mocks base method.
 func (m *MockIInputController) OnDiscardStatementEntry(i int) {
 	m.ctrl.T.Helper()
@@ -168,6 +236,18 @@ func (mr *MockIInputControllerMockRecorder) OnDisplayShortcutModal() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDisplayShortcutModal", reflect.TypeOf((*MockIInputController)(nil).OnDisplayShortcutModal))
 }
 
+// OnEditStatementEntry mocks base method.
+func (m *MockIInputController) OnEditStatementEntry(i int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnEditStatementEntry", i)
+}
+
+// OnEditStatementEntry indicates an expected call of OnEditStatementEntry.
+func (mr *MockIInputControllerMockRecorder) OnEditStatementEntry(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnEditStatementEntry", reflect.TypeOf((*MockIInputController)(nil).OnEditStatementEntry), i)
+}
+
 // OnFinishPosting mocks base method.
 func (m *MockIInputController) OnFinishPosting() {
 	m.ctrl.T.Helper()
@@ -180,6 +260,18 @@ func (mr *MockIInputControllerMockRecorder) OnFinishPosting() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnFinishPosting", reflect.TypeOf((*MockIInputController)(nil).OnFinishPosting))
 }
 
+// OnHidePresetBuilder mocks base method.
+func (m *MockIInputController) OnHidePresetBuilder() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnHidePresetBuilder")
+}
+
+// OnHidePresetBuilder indicates an expected call of OnHidePresetBuilder.
+func (mr *MockIInputControllerMockRecorder) OnHidePresetBuilder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnHidePresetBuilder", reflect.TypeOf((*MockIInputController)(nil).OnHidePresetBuilder))
+}
+
 // OnHideShortcutModal mocks base method.
 func (m *MockIInputController) OnHideShortcutModal() {
 	m.ctrl.T.Helper()
@@ -229,15 +321,15 @@ func (mr *MockIInputControllerMockRecorder) OnInputRejection() *gomock.Call {
 }
 
 // OnLoadStatement mocks base method.
-func (m *MockIInputController) OnLoadStatement(csvFile, presetFile string) {
+func (m *MockIInputController) OnLoadStatement(csvFile, presetFile, format string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "OnLoadStatement", csvFile, presetFile)
+	m.ctrl.Call(m, "OnLoadStatement", csvFile, presetFile, format)
 }
 
 // OnLoadStatement indicates an expected call of OnLoadStatement.
-func (mr *MockIInputControllerMockRecorder) OnLoadStatement(csvFile, presetFile interface{}) *gomock.Call {
+func (mr *MockIInputControllerMockRecorder) OnLoadStatement(csvFile, presetFile, format interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnLoadStatement", reflect.TypeOf((*MockIInputController)(nil).OnLoadStatement), csvFile, presetFile)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnLoadStatement", reflect.TypeOf((*MockIInputController)(nil).OnLoadStatement), csvFile, presetFile, format)
 }
 
 // OnLoadStatementRequest mocks base method.
@@ -336,6 +428,66 @@ func (mr *MockIInputControllerMockRecorder) OnPostingAmmountDone(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnPostingAmmountDone", reflect.TypeOf((*MockIInputController)(nil).OnPostingAmmountDone), arg0)
 }
 
+// OnPresetBuilderChanged mocks base method.
+func (m *MockIInputController) OnPresetBuilderChanged(config statementloader.Config) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnPresetBuilderChanged", config)
+}
+
+// OnPresetBuilderChanged indicates an expected call of OnPresetBuilderChanged.
+func (mr *MockIInputControllerMockRecorder) OnPresetBuilderChanged(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnPresetBuilderChanged", reflect.TypeOf((*MockIInputController)(nil).OnPresetBuilderChanged), config)
+}
+
+// OnResumeStatement mocks base method.
+func (m *MockIInputController) OnResumeStatement(resume bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnResumeStatement", resume)
+}
+
+// OnResumeStatement indicates an expected call of OnResumeStatement.
+func (mr *MockIInputControllerMockRecorder) OnResumeStatement(resume interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnResumeStatement", reflect.TypeOf((*MockIInputController)(nil).OnResumeStatement), resume)
+}
+
+// OnSavePreset mocks base method.
+func (m *MockIInputController) OnSavePreset(name string, config statementloader.Config) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnSavePreset", name, config)
+}
+
+// OnSavePreset indicates an expected call of OnSavePreset.
+func (mr *MockIInputControllerMockRecorder) OnSavePreset(name, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnSavePreset", reflect.TypeOf((*MockIInputController)(nil).OnSavePreset), name, config)
+}
+
+// OnSelectStatementEntry mocks base method.
+func (m *MockIInputController) OnSelectStatementEntry(i int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnSelectStatementEntry", i)
+}
+
+// OnSelectStatementEntry indicates an expected call of OnSelectStatementEntry.
+func (mr *MockIInputControllerMockRecorder) OnSelectStatementEntry(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnSelectStatementEntry", reflect.TypeOf((*MockIInputController)(nil).OnSelectStatementEntry), i)
+}
+
+// OnShowPresetBuilder mocks base method.
+func (m *MockIInputController) OnShowPresetBuilder() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnShowPresetBuilder")
+}
+
+// OnShowPresetBuilder indicates an expected call of OnShowPresetBuilder.
+func (mr *MockIInputControllerMockRecorder) OnShowPresetBuilder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnShowPresetBuilder", reflect.TypeOf((*MockIInputController)(nil).OnShowPresetBuilder))
+}
+
 // OnShowStatementModal mocks base method.
 func (m *MockIInputController) OnShowStatementModal() {
 	m.ctrl.T.Helper()
@@ -407,3 +559,27 @@ func (mr *MockIInputControllerMockRecorder) OnUndo() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnUndo", reflect.TypeOf((*MockIInputController)(nil).OnUndo))
 }
+
+// OnUnloadStatementSource mocks base method.
+func (m *MockIInputController) OnUnloadStatementSource(i int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnUnloadStatementSource", i)
+}
+
+// OnUnloadStatementSource indicates an expected call of OnUnloadStatementSource.
+func (mr *MockIInputControllerMockRecorder) OnUnloadStatementSource(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnUnloadStatementSource", reflect.TypeOf((*MockIInputController)(nil).OnUnloadStatementSource), i)
+}
+
+// OnUpdateStatementEntry mocks base method.
+func (m *MockIInputController) OnUpdateStatementEntry(i int, account, date, description, ammount string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnUpdateStatementEntry", i, account, date, description, ammount)
+}
+
+// OnUpdateStatementEntry indicates an expected call of OnUpdateStatementEntry.
+func (mr *MockIInputControllerMockRecorder) OnUpdateStatementEntry(i, account, date, description, ammount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnUpdateStatementEntry", reflect.TypeOf((*MockIInputController)(nil).OnUpdateStatementEntry), i, account, date, description, ammount)
+}
@@ -9,7 +9,7 @@ import (
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
-	finance "github.com/vitorqb/addledger/internal/finance"
+	dateguesser "github.com/vitorqb/addledger/internal/dateguesser"
 )
 
 // MockIClock is a mock of IClock interface.
@@ -73,16 +73,31 @@ func (m *MockIDateGuesser) EXPECT() *MockIDateGuesserMockRecorder {
 }
 
 // Guess mocks base method.
-func (m *MockIDateGuesser) Guess(userInput string, statementEntry finance.StatementEntry) (time.Time, bool) {
+func (m *MockIDateGuesser) Guess(inputs dateguesser.Inputs) (time.Time, bool) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Guess", userInput, statementEntry)
+	ret := m.ctrl.Call(m, "Guess", inputs)
 	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(bool)
 	return ret0, ret1
 }
 
 // Guess indicates an expected call of Guess.
-func (mr *MockIDateGuesserMockRecorder) Guess(userInput, statementEntry interface{}) *gomock.Call {
+func (mr *MockIDateGuesserMockRecorder) Guess(inputs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Guess", reflect.TypeOf((*MockIDateGuesser)(nil).Guess), userInput, statementEntry)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Guess", reflect.TypeOf((*MockIDateGuesser)(nil).Guess), inputs)
+}
+
+// Interpret mocks base method.
+func (m *MockIDateGuesser) Interpret(inputs dateguesser.Inputs) (dateguesser.Interpretation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Interpret", inputs)
+	ret0, _ := ret[0].(dateguesser.Interpretation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Interpret indicates an expected call of Interpret.
+func (mr *MockIDateGuesserMockRecorder) Interpret(inputs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Interpret", reflect.TypeOf((*MockIDateGuesser)(nil).Interpret), inputs)
 }
@@ -34,15 +34,15 @@ func (m *MockLoadStatementModalController) EXPECT() *MockLoadStatementModalContr
 }
 
 // OnLoadStatement mocks base method.
-func (m *MockLoadStatementModalController) OnLoadStatement(csvFile, presetFile string) {
+func (m *MockLoadStatementModalController) OnLoadStatement(csvFile, presetFile, format string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "OnLoadStatement", csvFile, presetFile)
+	m.ctrl.Call(m, "OnLoadStatement", csvFile, presetFile, format)
 }
 
 // OnLoadStatement indicates an expected call of OnLoadStatement.
-func (mr *MockLoadStatementModalControllerMockRecorder) OnLoadStatement(csvFile, presetFile interface{}) *gomock.Call {
+func (mr *MockLoadStatementModalControllerMockRecorder) OnLoadStatement(csvFile, presetFile, format interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnLoadStatement", reflect.TypeOf((*MockLoadStatementModalController)(nil).OnLoadStatement), csvFile, presetFile)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnLoadStatement", reflect.TypeOf((*MockLoadStatementModalController)(nil).OnLoadStatement), csvFile, presetFile, format)
 }
 
 // MockState is a mock of State interface.
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: presetbuilder.go
+
+// Package mock_display is a generated GoMock package.
+package mock_display
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	statementloader "github.com/vitorqb/addledger/internal/services/statementloader"
+)
+
+// MockPresetBuilderController is a mock of PresetBuilderController interface.
+type MockPresetBuilderController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresetBuilderControllerMockRecorder
+}
+
+// MockPresetBuilderControllerMockRecorder is the mock recorder for MockPresetBuilderController.
+type MockPresetBuilderControllerMockRecorder struct {
+	mock *MockPresetBuilderController
+}
+
+// NewMockPresetBuilderController creates a new mock instance.
+func NewMockPresetBuilderController(ctrl *gomock.Controller) *MockPresetBuilderController {
+	mock := &MockPresetBuilderController{ctrl: ctrl}
+	mock.recorder = &MockPresetBuilderControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresetBuilderController) EXPECT() *MockPresetBuilderControllerMockRecorder {
+	return m.recorder
+}
+
+// OnHidePresetBuilder mocks base method.
+func (m *MockPresetBuilderController) OnHidePresetBuilder() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnHidePresetBuilder")
+}
+
+// OnHidePresetBuilder indicates an expected call of OnHidePresetBuilder.
+func (mr *MockPresetBuilderControllerMockRecorder) OnHidePresetBuilder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnHidePresetBuilder", reflect.TypeOf((*MockPresetBuilderController)(nil).OnHidePresetBuilder))
+}
+
+// OnPresetBuilderChanged mocks base method.
+func (m *MockPresetBuilderController) OnPresetBuilderChanged(config statementloader.Config) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnPresetBuilderChanged", config)
+}
+
+// OnPresetBuilderChanged indicates an expected call of OnPresetBuilderChanged.
+func (mr *MockPresetBuilderControllerMockRecorder) OnPresetBuilderChanged(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnPresetBuilderChanged", reflect.TypeOf((*MockPresetBuilderController)(nil).OnPresetBuilderChanged), config)
+}
+
+// OnSavePreset mocks base method.
+func (m *MockPresetBuilderController) OnSavePreset(name string, config statementloader.Config) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnSavePreset", name, config)
+}
+
+// OnSavePreset indicates an expected call of OnSavePreset.
+func (mr *MockPresetBuilderControllerMockRecorder) OnSavePreset(name, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnSavePreset", reflect.TypeOf((*MockPresetBuilderController)(nil).OnSavePreset), name, config)
+}
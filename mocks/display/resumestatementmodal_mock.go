@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: resumestatementmodal.go
+
+// Package mock_display is a generated GoMock package.
+package mock_display
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockResumeStatementModalController is a mock of ResumeStatementModalController interface.
+type MockResumeStatementModalController struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumeStatementModalControllerMockRecorder
+}
+
+// MockResumeStatementModalControllerMockRecorder is the mock recorder for MockResumeStatementModalController.
+type MockResumeStatementModalControllerMockRecorder struct {
+	mock *MockResumeStatementModalController
+}
+
+// NewMockResumeStatementModalController creates a new mock instance.
+func NewMockResumeStatementModalController(ctrl *gomock.Controller) *MockResumeStatementModalController {
+	mock := &MockResumeStatementModalController{ctrl: ctrl}
+	mock.recorder = &MockResumeStatementModalControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumeStatementModalController) EXPECT() *MockResumeStatementModalControllerMockRecorder {
+	return m.recorder
+}
+
+// OnResumeStatement mocks base method.
+func (m *MockResumeStatementModalController) OnResumeStatement(resume bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnResumeStatement", resume)
+}
+
+// OnResumeStatement indicates an expected call of OnResumeStatement.
+func (mr *MockResumeStatementModalControllerMockRecorder) OnResumeStatement(resume interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnResumeStatement", reflect.TypeOf((*MockResumeStatementModalController)(nil).OnResumeStatement), resume)
+}
@@ -45,6 +45,18 @@ func (mr *MockShortcutModalControllerMockRecorder) OnHideShortcutModal() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnHideShortcutModal", reflect.TypeOf((*MockShortcutModalController)(nil).OnHideShortcutModal))
 }
 
+// OnShowPresetBuilder mocks base method.
+func (m *MockShortcutModalController) OnShowPresetBuilder() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnShowPresetBuilder")
+}
+
+// OnShowPresetBuilder indicates an expected call of OnShowPresetBuilder.
+func (mr *MockShortcutModalControllerMockRecorder) OnShowPresetBuilder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnShowPresetBuilder", reflect.TypeOf((*MockShortcutModalController)(nil).OnShowPresetBuilder))
+}
+
 // OnShowStatementModal mocks base method.
 func (m *MockShortcutModalController) OnShowStatementModal() {
 	m.ctrl.T.Helper()
@@ -33,6 +33,30 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// CancelStatementEntryEdit mocks base method.
+func (m *MockController) CancelStatementEntryEdit() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelStatementEntryEdit")
+}
+
+// CancelStatementEntryEdit indicates an expected call of CancelStatementEntryEdit.
+func (mr *MockControllerMockRecorder) CancelStatementEntryEdit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelStatementEntryEdit", reflect.TypeOf((*MockController)(nil).CancelStatementEntryEdit))
+}
+
+// DiscardDuplicatedStatementEntries mocks base method.
+func (m *MockController) DiscardDuplicatedStatementEntries() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardDuplicatedStatementEntries")
+}
+
+// DiscardDuplicatedStatementEntries indicates an expected call of DiscardDuplicatedStatementEntries.
+func (mr *MockControllerMockRecorder) DiscardDuplicatedStatementEntries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDuplicatedStatementEntries", reflect.TypeOf((*MockController)(nil).DiscardDuplicatedStatementEntries))
+}
+
 // DiscardStatementEntry mocks base method.
 func (m *MockController) DiscardStatementEntry(index int) {
 	m.ctrl.T.Helper()
@@ -45,6 +69,18 @@ func (mr *MockControllerMockRecorder) DiscardStatementEntry(index interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardStatementEntry", reflect.TypeOf((*MockController)(nil).DiscardStatementEntry), index)
 }
 
+// EditStatementEntry mocks base method.
+func (m *MockController) EditStatementEntry(index int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EditStatementEntry", index)
+}
+
+// EditStatementEntry indicates an expected call of EditStatementEntry.
+func (mr *MockControllerMockRecorder) EditStatementEntry(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditStatementEntry", reflect.TypeOf((*MockController)(nil).EditStatementEntry), index)
+}
+
 // HideModal mocks base method.
 func (m *MockController) HideModal() {
 	m.ctrl.T.Helper()
@@ -68,3 +104,39 @@ func (mr *MockControllerMockRecorder) LoadRequest() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRequest", reflect.TypeOf((*MockController)(nil).LoadRequest))
 }
+
+// UnloadStatementSource mocks base method.
+func (m *MockController) UnloadStatementSource(index int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnloadStatementSource", index)
+}
+
+// UnloadStatementSource indicates an expected call of UnloadStatementSource.
+func (mr *MockControllerMockRecorder) UnloadStatementSource(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnloadStatementSource", reflect.TypeOf((*MockController)(nil).UnloadStatementSource), index)
+}
+
+// UpdateStatementEntry mocks base method.
+func (m *MockController) UpdateStatementEntry(index int, account, date, description, ammount string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateStatementEntry", index, account, date, description, ammount)
+}
+
+// UpdateStatementEntry indicates an expected call of UpdateStatementEntry.
+func (mr *MockControllerMockRecorder) UpdateStatementEntry(index, account, date, description, ammount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatementEntry", reflect.TypeOf((*MockController)(nil).UpdateStatementEntry), index, account, date, description, ammount)
+}
+
+// UseStatementEntry mocks base method.
+func (m *MockController) UseStatementEntry(index int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UseStatementEntry", index)
+}
+
+// UseStatementEntry indicates an expected call of UseStatementEntry.
+func (mr *MockControllerMockRecorder) UseStatementEntry(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStatementEntry", reflect.TypeOf((*MockController)(nil).UseStatementEntry), index)
+}
@@ -49,6 +49,21 @@ func (mr *MockIClientMockRecorder) Accounts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accounts", reflect.TypeOf((*MockIClient)(nil).Accounts))
 }
 
+// DefaultCommodity mocks base method.
+func (m *MockIClient) DefaultCommodity() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefaultCommodity")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DefaultCommodity indicates an expected call of DefaultCommodity.
+func (mr *MockIClientMockRecorder) DefaultCommodity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultCommodity", reflect.TypeOf((*MockIClient)(nil).DefaultCommodity))
+}
+
 // Transactions mocks base method.
 func (m *MockIClient) Transactions() ([]journal.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -47,6 +47,20 @@ func (mr *MockIMetaLoaderMockRecorder) LoadAccounts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAccounts", reflect.TypeOf((*MockIMetaLoader)(nil).LoadAccounts))
 }
 
+// LoadDefaultCommodity mocks base method.
+func (m *MockIMetaLoader) LoadDefaultCommodity() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDefaultCommodity")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadDefaultCommodity indicates an expected call of LoadDefaultCommodity.
+func (mr *MockIMetaLoaderMockRecorder) LoadDefaultCommodity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDefaultCommodity", reflect.TypeOf((*MockIMetaLoader)(nil).LoadDefaultCommodity))
+}
+
 // LoadTransactions mocks base method.
 func (m *MockIMetaLoader) LoadTransactions() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statementimporter.go
+
+// Package mock_statementimporter is a generated GoMock package.
+package mock_statementimporter
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatementLoader is a mock of StatementLoader interface.
+type MockStatementLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementLoaderMockRecorder
+}
+
+// MockStatementLoaderMockRecorder is the mock recorder for MockStatementLoader.
+type MockStatementLoaderMockRecorder struct {
+	mock *MockStatementLoader
+}
+
+// NewMockStatementLoader creates a new mock instance.
+func NewMockStatementLoader(ctrl *gomock.Controller) *MockStatementLoader {
+	mock := &MockStatementLoader{ctrl: ctrl}
+	mock.recorder = &MockStatementLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementLoader) EXPECT() *MockStatementLoaderMockRecorder {
+	return m.recorder
+}
+
+// LoadFromFiles mocks base method.
+func (m *MockStatementLoader) LoadFromFiles(statementFile, presetFile, format string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFromFiles", statementFile, presetFile, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadFromFiles indicates an expected call of LoadFromFiles.
+func (mr *MockStatementLoaderMockRecorder) LoadFromFiles(statementFile, presetFile, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFromFiles", reflect.TypeOf((*MockStatementLoader)(nil).LoadFromFiles), statementFile, presetFile, format)
+}
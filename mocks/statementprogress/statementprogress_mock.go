@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statementprogress.go
+
+// Package mock_statementprogress is a generated GoMock package.
+package mock_statementprogress
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	state "github.com/vitorqb/addledger/internal/state"
+)
+
+// MockIStore is a mock of IStore interface.
+type MockIStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStoreMockRecorder
+}
+
+// MockIStoreMockRecorder is the mock recorder for MockIStore.
+type MockIStoreMockRecorder struct {
+	mock *MockIStore
+}
+
+// NewMockIStore creates a new mock instance.
+func NewMockIStore(ctrl *gomock.Controller) *MockIStore {
+	mock := &MockIStore{ctrl: ctrl}
+	mock.recorder = &MockIStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStore) EXPECT() *MockIStoreMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockIStore) Load(key string) (state.StatementProgress, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", key)
+	ret0, _ := ret[0].(state.StatementProgress)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockIStoreMockRecorder) Load(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockIStore)(nil).Load), key)
+}
+
+// Save mocks base method.
+func (m *MockIStore) Save(key string, progress state.StatementProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", key, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIStoreMockRecorder) Save(key, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIStore)(nil).Save), key, progress)
+}
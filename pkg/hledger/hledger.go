@@ -3,6 +3,7 @@ package hledger
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -19,6 +20,9 @@ type IClient interface {
 	Accounts() ([]journal.Account, error)
 	// Transactions returns a list of all known transactions.
 	Transactions() ([]journal.Transaction, error)
+	// DefaultCommodity returns the commodity declared with the `D` directive,
+	// or an empty string if there is none.
+	DefaultCommodity() (string, error)
 }
 
 var _ IClient = &Client{}
@@ -89,6 +93,58 @@ func (c *Client) Transactions() ([]journal.Transaction, error) {
 	return transactions, nil
 }
 
+func (c *Client) DefaultCommodity() (string, error) {
+	cmdArgs := []string{"files"}
+	if c.ledgerFile != "" {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--file=%s", c.ledgerFile))
+	}
+	cmd := exec.Command(c.executable, cmdArgs...)
+	cmdOutputBytes, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get journal files: %w", err)
+	}
+	commodity := ""
+	for _, file := range strings.Split(strings.TrimSpace(string(cmdOutputBytes)), "\n") {
+		if file == "" {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read journal file %s: %w", file, err)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if directiveCommodity, found := ParseDefaultCommodityDirective(line); found {
+				commodity = directiveCommodity
+			}
+		}
+	}
+	return commodity, nil
+}
+
+// ParseDefaultCommodityDirective parses the commodity from a `D` directive
+// line, e.g. `D 1,000.00 EUR` or `D $1000`.
+func ParseDefaultCommodityDirective(line string) (string, bool) {
+	if !strings.HasPrefix(line, "D ") && !strings.HasPrefix(line, "D\t") {
+		return "", false
+	}
+	ammount := line[2:]
+	if i := strings.Index(ammount, ";"); i != -1 {
+		ammount = ammount[:i]
+	}
+	ammount = strings.TrimSpace(ammount)
+	if strings.HasPrefix(ammount, "\"") {
+		if end := strings.Index(ammount[1:], "\""); end != -1 {
+			return ammount[1 : end+1], true
+		}
+		return "", false
+	}
+	commodity := strings.Trim(ammount, "0123456789.,-+ \t")
+	if commodity == "" {
+		return "", false
+	}
+	return commodity, true
+}
+
 func NewClient(executable, ledgerFile string) *Client {
 	return &Client{
 		executable: executable,
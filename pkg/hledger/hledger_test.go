@@ -96,4 +96,39 @@ func TestClient(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, expectedTransactions, transactions)
 	})
+	t.Run("DefaultCommodity (ledger file)", func(t *testing.T) {
+		client := NewClient(tu.TestDataPath(t, "fake_hledger.sh"), "foo")
+		commodity, err := client.DefaultCommodity()
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", commodity)
+	})
+	t.Run("DefaultCommodity (error)", func(t *testing.T) {
+		client := NewClient(tu.TestDataPath(t, "fake_hledger.sh"), "bar")
+		_, err := client.DefaultCommodity()
+		assert.ErrorContains(t, err, "failed to get journal files")
+	})
+}
+
+func TestParseDefaultCommodityDirective(t *testing.T) {
+	type testcase struct {
+		line     string
+		expected string
+		found    bool
+	}
+	testcases := []testcase{
+		{line: "D 1,000.00 EUR", expected: "EUR", found: true},
+		{line: "D $1000", expected: "$", found: true},
+		{line: "D\tBRL -1.000,00 ; comment", expected: "BRL", found: true},
+		{line: `D "ACME Corp" 10`, expected: "ACME Corp", found: true},
+		{line: "D 1000", found: false},
+		{line: "2023-01-01 Description", found: false},
+		{line: "  D 1000 EUR", found: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.line, func(t *testing.T) {
+			commodity, found := ParseDefaultCommodityDirective(tc.line)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, commodity)
+		})
+	}
 }
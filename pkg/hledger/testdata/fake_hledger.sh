@@ -48,5 +48,13 @@ then
     exit 0
 fi
 
+# Case 4 - `files` w/ file
+if [[ "$1" == "files" ]] && [[ "$2" == "--file=foo" ]] && [[ "$#" == "2" ]]
+then
+    echo "${SCRIPT_DIR}/main.journal"
+    echo "${SCRIPT_DIR}/included.journal"
+    exit 0
+fi
+
 echo "ERROR: UNEXPECTED COMMAND" >&2
 exit 1